@@ -0,0 +1,98 @@
+package geo
+
+import (
+	"context"
+)
+
+// The outcome of parsing a single value of a batch.
+type parseResult struct {
+	point *Point
+	err   error
+}
+
+// A value of a batch together with the slot its result is delivered to.
+type parseJob struct {
+	value  string
+	result chan parseResult
+}
+
+// Parses the values read from the passed in channel using the passed in number of workers.
+// The Points are sent to the returned Point channel in the same order as their values were read,
+// values which can not be parsed are reported on the returned error channel instead.
+// Both channels are closed once all values have been consumed, and both have to be drained
+// by the caller, since a pending error blocks the delivery of the following Points and vice versa.
+func ParseBatch(values <-chan string, workers int) (<-chan *Point, <-chan error) {
+	return ParseBatchContext(context.Background(), values, workers)
+}
+
+// Same as ParseBatch, but stops reading values and closes both channels early
+// once the passed in context is cancelled.
+func ParseBatchContext(ctx context.Context, values <-chan string, workers int) (<-chan *Point, <-chan error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	points := make(chan *Point)
+	errs := make(chan error)
+	jobs := make(chan parseJob)
+	// Holds the result slots in input order, which bounds the number of values in flight
+	pending := make(chan chan parseResult, workers)
+
+	go func() {
+		defer close(jobs)
+		defer close(pending)
+		for {
+			var value string
+			var ok bool
+			select {
+			case value, ok = <-values:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			job := parseJob{value: value, result: make(chan parseResult, 1)}
+			select {
+			case pending <- job.result:
+			case <-ctx.Done():
+				return
+			}
+			jobs <- job
+		}
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			var ps Parser
+			for job := range jobs {
+				p, err := ps.Parse(job.value)
+				job.result <- parseResult{point: p, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(points)
+		defer close(errs)
+		for result := range pending {
+			r := <-result
+			if r.err != nil {
+				select {
+				case errs <- r.err:
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+			select {
+			case points <- r.point:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return points, errs
+}
@@ -0,0 +1,100 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// Feeds the passed in values into a channel and closes it.
+func feedValues(values []string) <-chan string {
+	in := make(chan string)
+	go func() {
+		defer close(in)
+		for _, v := range values {
+			in <- v
+		}
+	}()
+	return in
+}
+
+// Drains both channels returned by ParseBatch until they are closed.
+func drainBatch(points <-chan *Point, errs <-chan error) ([]*Point, []error) {
+	var ps []*Point
+	var es []error
+	for points != nil || errs != nil {
+		select {
+		case p, ok := <-points:
+			if !ok {
+				points = nil
+				continue
+			}
+			ps = append(ps, p)
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			es = append(es, err)
+		}
+	}
+	return ps, es
+}
+
+// Ensures that the Points are delivered in input order regardless of the number of workers.
+func TestParseBatchOrder(t *testing.T) {
+	values := make([]string, 1000)
+	for i := range values {
+		values[i] = fmt.Sprintf("%d.5,%d.25", i%90, i%180)
+	}
+
+	points, errs := drainBatch(ParseBatch(feedValues(values), 8))
+	if len(errs) != 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected no errors, got %v", errs))
+	}
+	if len(points) != len(values) {
+		t.Fatal("Unnacceptable result.", fmt.Sprintf("Expected %d Points, got %d", len(values), len(points)))
+	}
+	for i, p := range points {
+		if p.Lat() != float64(i%90)+0.5 || p.Lng() != float64(i%180)+0.25 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Point %d out of order: %v", i, p))
+			break
+		}
+	}
+}
+
+// Ensures that values which can not be parsed are reported without stopping the batch.
+func TestParseBatchPartialFailure(t *testing.T) {
+	values := []string{"1,2", "garbage", "3,4", "", "5,6"}
+
+	points, errs := drainBatch(ParseBatch(feedValues(values), 3))
+	if len(errs) != 2 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected 2 errors, got %v", errs))
+	}
+	if len(points) != 3 || points[0].Lat() != 1 || points[1].Lat() != 3 || points[2].Lat() != 5 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected Points 1, 3 and 5 in order, got %v", points))
+	}
+}
+
+// Ensures that cancelling the context closes both channels even though input is still available.
+func TestParseBatchContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan string)
+	go func() {
+		for {
+			select {
+			case in <- "1,2":
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	points, errs := ParseBatchContext(ctx, in, 4)
+	for i := 0; i < 10; i++ {
+		<-points
+	}
+	cancel()
+
+	drainBatch(points, errs)
+}
@@ -0,0 +1,351 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Returned by Union and Difference for operands with an edge crossing the antimeridian,
+// which the clipper working in the lat/lng plane does not support.
+var ErrAntimeridianOperand = errors.New("polygon crosses the antimeridian")
+
+// The default area (in square degrees) below which Union and Difference drop resulting rings as slivers.
+const DefaultSliverArea = 1e-10
+
+// The distance (in degrees) within which the clipper considers points equal.
+const clipEpsilon = 1e-12
+
+// Returns the union of the current Polygon and the passed in Polygon, dropping slivers below DefaultSliverArea.
+// See UnionWithTolerance.
+func (p *Polygon) Union(other *Polygon) ([]*Polygon, error) {
+	return p.UnionWithTolerance(other, DefaultSliverArea)
+}
+
+// Returns the part of the current Polygon not covered by the passed in Polygon,
+// dropping slivers below DefaultSliverArea.  See DifferenceWithTolerance.
+func (p *Polygon) Difference(other *Polygon) ([]*Polygon, error) {
+	return p.DifferenceWithTolerance(other, DefaultSliverArea)
+}
+
+// Returns the union of the current Polygon and the passed in Polygon as a number of disjoint Polygons.
+// Both operands have to be single rings without self-intersections, and must not cross the antimeridian,
+// in which case ErrAntimeridianOperand is returned.  Edges are treated as straight lines in the lat/lng plane.
+// Like in the Weiler-Atherton algorithm, the edges of both operands are split where they meet, classified
+// against the other operand and traced into the rings of the result.  Edges shared by both operands are
+// merged exactly, so adjacent polygons are joined without seams.  Rings with an area below the passed in
+// tolerance (in square degrees) are dropped as slivers.
+// The Polygons of the result keep their holes apart from their outer rings, see Holes.
+func (p *Polygon) UnionWithTolerance(other *Polygon, tolerance float64) ([]*Polygon, error) {
+	return clipPolygons(p, other, true, tolerance)
+}
+
+// Returns the part of the current Polygon not covered by the passed in Polygon, as a number of disjoint Polygons,
+// possibly with holes.  The operands and the result are subject to the same rules as for UnionWithTolerance.
+func (p *Polygon) DifferenceWithTolerance(other *Polygon, tolerance float64) ([]*Polygon, error) {
+	return clipPolygons(p, other, false, tolerance)
+}
+
+// A part of an edge of one of the operands, between two canonical points of a clipPool.
+type clipFragment struct {
+	from, to *Point
+	used     bool
+}
+
+// Holds the distinct points of a clipping operation, so points equal within clipEpsilon are represented by the same Point.
+type clipPool struct {
+	points []*Point
+}
+
+// Returns the point of the pool equal to the passed in point, adding it if there is none.
+func (cp *clipPool) canonical(p *Point) *Point {
+	for _, q := range cp.points {
+		if math.Abs(q.lat-p.lat) <= clipEpsilon && math.Abs(q.lng-p.lng) <= clipEpsilon {
+			return q
+		}
+	}
+	cp.points = append(cp.points, p)
+	return p
+}
+
+// Performs the union or the difference of the passed in operands.
+func clipPolygons(a, b *Polygon, union bool, tolerance float64) ([]*Polygon, error) {
+	ringA, err := clipRing(a)
+	if err != nil {
+		return nil, err
+	}
+	ringB, err := clipRing(b)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := &clipPool{}
+	for i := range ringA {
+		ringA[i] = pool.canonical(ringA[i])
+	}
+	for i := range ringB {
+		ringB[i] = pool.canonical(ringB[i])
+	}
+	ringA, ringB = dropRepeatedPoints(ringA), dropRepeatedPoints(ringB)
+	fragmentsA, fragmentsB := splitRings(ringA, ringB, pool)
+
+	shared := map[[2]*Point]bool{}
+	for _, f := range fragmentsB {
+		shared[[2]*Point{f.from, f.to}] = true
+	}
+
+	kept := []*clipFragment{}
+	for _, f := range fragmentsA {
+		same, opposite := shared[[2]*Point{f.from, f.to}], shared[[2]*Point{f.to, f.from}]
+		switch {
+		case same:
+			if union {
+				kept = append(kept, f)
+			}
+		case opposite:
+			if !union {
+				kept = append(kept, f)
+			}
+		case !ringContains(ringB, segmentMidpoint(f.from, f.to)):
+			kept = append(kept, f)
+		}
+	}
+	sharedA := map[[2]*Point]bool{}
+	for _, f := range fragmentsA {
+		sharedA[[2]*Point{f.from, f.to}] = true
+		sharedA[[2]*Point{f.to, f.from}] = true
+	}
+	for _, f := range fragmentsB {
+		if sharedA[[2]*Point{f.from, f.to}] {
+			continue
+		}
+		inside := ringContains(ringA, segmentMidpoint(f.from, f.to))
+		if union && !inside {
+			kept = append(kept, f)
+		} else if !union && inside {
+			kept = append(kept, &clipFragment{from: f.to, to: f.from})
+		}
+	}
+
+	return assembleRings(traceRings(kept), tolerance), nil
+}
+
+// Returns the points of the passed in Polygon as a counter clockwise ring without a closing point,
+// or an error if it is no single ring or crosses the antimeridian.
+func clipRing(p *Polygon) ([]*Point, error) {
+	if len(p.holes) > 0 {
+		return nil, errors.New("the operands must be single rings")
+	}
+	ring := append([]*Point(nil), p.points...)
+	if len(ring) > 1 && ring[0].lat == ring[len(ring)-1].lat && ring[0].lng == ring[len(ring)-1].lng {
+		ring = ring[:len(ring)-1]
+	}
+	if len(ring) < 3 {
+		return nil, errors.New("a polygon needs at least three points")
+	}
+	for i, point := range ring {
+		if i > 0 && point.lat == ring[0].lat && point.lng == ring[0].lng {
+			return nil, errors.New("the operands must be single rings")
+		}
+		if math.Abs(point.lng-ring[(i+1)%len(ring)].lng) > 180 {
+			return nil, ErrAntimeridianOperand
+		}
+	}
+
+	if NewPolygonNoCopy(ring).signedArea() < 0 {
+		for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+			ring[i], ring[j] = ring[j], ring[i]
+		}
+	}
+	return ring, nil
+}
+
+// Returns the passed in ring of canonical points without points repeating their predecessor.
+func dropRepeatedPoints(ring []*Point) []*Point {
+	distinct := []*Point{}
+	for i, point := range ring {
+		if point != ring[(i+len(ring)-1)%len(ring)] {
+			distinct = append(distinct, point)
+		}
+	}
+	return distinct
+}
+
+// Splits the edges of both rings wherever they cross, touch or overlap each other, and returns their fragments.
+func splitRings(ringA, ringB []*Point, pool *clipPool) (fragmentsA, fragmentsB []*clipFragment) {
+	splitsA := make([][]float64, len(ringA))
+	splitsB := make([][]float64, len(ringB))
+	for i := range ringA {
+		p, p2 := ringA[i], ringA[(i+1)%len(ringA)]
+		for j := range ringB {
+			q, q2 := ringB[j], ringB[(j+1)%len(ringB)]
+			t, u := edgeSplits(p, p2, q, q2)
+			splitsA[i] = append(splitsA[i], t...)
+			splitsB[j] = append(splitsB[j], u...)
+		}
+	}
+	return fragmentRing(ringA, splitsA, pool), fragmentRing(ringB, splitsB, pool)
+}
+
+// Returns the positions (as fractions) along the segments p-p2 and q-q2 where they meet,
+// including the ends of either segment lying on the other one.
+func edgeSplits(p, p2, q, q2 *Point) (t, u []float64) {
+	rx, ry := p2.lng-p.lng, p2.lat-p.lat
+	sx, sy := q2.lng-q.lng, q2.lat-q.lat
+	qx, qy := q.lng-p.lng, q.lat-p.lat
+	rLen, sLen := math.Hypot(rx, ry), math.Hypot(sx, sy)
+
+	denom := rx*sy - ry*sx
+	if math.Abs(denom) > clipEpsilon*rLen*sLen {
+		tp := (qx*sy - qy*sx) / denom
+		up := (qx*ry - qy*rx) / denom
+		if tp >= -clipEpsilon/rLen && tp <= 1+clipEpsilon/rLen && up >= -clipEpsilon/sLen && up <= 1+clipEpsilon/sLen {
+			t = append(t, tp)
+			u = append(u, up)
+		}
+		return t, u
+	}
+
+	// Parallel segments only meet if they are collinear
+	if math.Abs(qx*ry-qy*rx) > clipEpsilon*rLen {
+		return nil, nil
+	}
+	for _, end := range []*Point{q, q2} {
+		if f := ((end.lng-p.lng)*rx + (end.lat-p.lat)*ry) / (rLen * rLen); f >= 0 && f <= 1 {
+			t = append(t, f)
+		}
+	}
+	for _, end := range []*Point{p, p2} {
+		if f := ((end.lng-q.lng)*sx + (end.lat-q.lat)*sy) / (sLen * sLen); f >= 0 && f <= 1 {
+			u = append(u, f)
+		}
+	}
+	return t, u
+}
+
+// Returns the fragments of the edges of the passed in ring, split at the passed in positions along each edge.
+func fragmentRing(ring []*Point, splits [][]float64, pool *clipPool) []*clipFragment {
+	fragments := []*clipFragment{}
+	for i, from := range ring {
+		to := ring[(i+1)%len(ring)]
+		sort.Float64s(splits[i])
+
+		prev := from
+		for _, f := range append(splits[i], 1) {
+			var next *Point
+			if f >= 1 {
+				next = to
+			} else {
+				next = pool.canonical(NewPoint(from.lat+f*(to.lat-from.lat), from.lng+f*(to.lng-from.lng)))
+			}
+			if next != prev {
+				fragments = append(fragments, &clipFragment{from: prev, to: next})
+				prev = next
+			}
+		}
+	}
+	return fragments
+}
+
+// Returns the midpoint of the segment a-b in the lat/lng plane.
+func segmentMidpoint(a, b *Point) *Point {
+	return NewPoint((a.lat+b.lat)/2, (a.lng+b.lng)/2)
+}
+
+// Links the passed in fragments into closed rings.  Where several fragments leave the same point,
+// the one turning right the most is followed, which keeps rings touching in a single point apart.
+func traceRings(fragments []*clipFragment) [][]*Point {
+	outgoing := map[*Point][]*clipFragment{}
+	for _, f := range fragments {
+		outgoing[f.from] = append(outgoing[f.from], f)
+	}
+
+	rings := [][]*Point{}
+	for _, start := range fragments {
+		if start.used {
+			continue
+		}
+		start.used = true
+		ring := []*Point{start.from}
+		current := start
+		for current.to != start.from {
+			ring = append(ring, current.to)
+			var next *clipFragment
+			bestTurn := math.Inf(1)
+			for _, candidate := range outgoing[current.to] {
+				if candidate.used {
+					continue
+				}
+				inX, inY := current.to.lng-current.from.lng, current.to.lat-current.from.lat
+				outX, outY := candidate.to.lng-candidate.from.lng, candidate.to.lat-candidate.from.lat
+				if turn := math.Atan2(inX*outY-inY*outX, inX*outX+inY*outY); turn < bestTurn {
+					next, bestTurn = candidate, turn
+				}
+			}
+			if next == nil {
+				// Left open by a degenerate input, it is not part of the result
+				ring = nil
+				break
+			}
+			next.used = true
+			current = next
+		}
+		if ring != nil {
+			rings = append(rings, removeCollinear(ring))
+		}
+	}
+	return rings
+}
+
+// Returns the passed in ring without the points lying on the line between their neighbours.
+func removeCollinear(ring []*Point) []*Point {
+	for changed := true; changed && len(ring) > 3; {
+		changed = false
+		for i := range ring {
+			prev, next := ring[(i+len(ring)-1)%len(ring)], ring[(i+1)%len(ring)]
+			length := math.Hypot(next.lng-prev.lng, next.lat-prev.lat)
+			if math.Abs(orientation(prev, ring[i], next)) <= clipEpsilon*length {
+				ring = append(ring[:i], ring[i+1:]...)
+				changed = true
+				break
+			}
+		}
+	}
+	return ring
+}
+
+// Sorts the passed in rings into counter clockwise outer rings and clockwise holes, drops the rings
+// smaller than the passed in tolerance (in square degrees), and returns every outer ring with its holes as a Polygon.
+func assembleRings(rings [][]*Point, tolerance float64) []*Polygon {
+	outers, outerAreas, holes := [][]*Point{}, []float64{}, [][]*Point{}
+	for _, ring := range rings {
+		area := NewPolygonNoCopy(ring).signedArea()
+		switch {
+		case area > tolerance:
+			outers = append(outers, ring)
+			outerAreas = append(outerAreas, area)
+		case area < -tolerance:
+			holes = append(holes, ring)
+		}
+	}
+
+	holesOf := make([][][]*Point, len(outers))
+	for _, hole := range holes {
+		probe := segmentMidpoint(hole[0], hole[1])
+		best := -1
+		for i, outer := range outers {
+			if ringContains(outer, probe) && (best < 0 || outerAreas[i] < outerAreas[best]) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			holesOf[best] = append(holesOf[best], hole)
+		}
+	}
+
+	polygons := make([]*Polygon, 0, len(outers))
+	for i, outer := range outers {
+		polygons = append(polygons, &Polygon{points: outer, holes: holesOf[i]})
+	}
+	return polygons
+}
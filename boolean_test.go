@@ -0,0 +1,265 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Returns a Polygon of the box spanned by the passed in corners.
+func boxPolygon(minLat, minLng, maxLat, maxLng float64) *Polygon {
+	return NewPolygon([]*Point{NewPoint(minLat, minLng), NewPoint(minLat, maxLng), NewPoint(maxLat, maxLng), NewPoint(maxLat, minLng)})
+}
+
+// Returns the total area (in square degrees) of the passed in polygons.
+// The areas of their holes are subtracted.
+func polygonsArea(polygons []*Polygon) float64 {
+	area := 0.0
+	for _, p := range polygons {
+		area += p.signedArea()
+	}
+	return area
+}
+
+// Ensures that two overlapping boxes are merged into one polygon.
+func TestUnionOverlapping(t *testing.T) {
+	a := boxPolygon(0, 0, 2, 2)
+	b := boxPolygon(1, 1, 3, 3)
+	union, err := a.Union(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(union) != 1 || len(union[0].Points()) != 8 {
+		t.Fatalf("Expected a single polygon of 8 points, but got %v instead", union)
+	}
+	if area := polygonsArea(union); math.Abs(area-7) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected an area of 7, but got %v", area))
+	}
+	for _, p := range []*Point{NewPoint(0.5, 0.5), NewPoint(1.5, 1.5), NewPoint(2.5, 2.5)} {
+		if !union[0].Contains(p) {
+			t.Error("Expected the union to contain", p)
+		}
+	}
+	if union[0].Contains(NewPoint(2.5, 0.5)) {
+		t.Error("Expected the union not to contain [2.5, 0.5]")
+	}
+}
+
+// Ensures that the union of two disjoint boxes consists of both of them.
+func TestUnionDisjoint(t *testing.T) {
+	union, err := boxPolygon(0, 0, 1, 1).Union(boxPolygon(5, 5, 6, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(union) != 2 {
+		t.Fatalf("Expected two polygons, but got %v instead", union)
+	}
+	if area := polygonsArea(union); math.Abs(area-3) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected an area of 3, but got %v", area))
+	}
+}
+
+// Ensures that boxes sharing an edge are merged without a seam, even if their corners differ by rounding errors.
+func TestUnionSharedEdge(t *testing.T) {
+	a := boxPolygon(0, 0, 1, 1)
+	b := boxPolygon(0, 1+1e-13, 1, 2)
+	union, err := a.Union(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(union) != 1 || len(union[0].Points()) != 4 {
+		t.Fatalf("Expected a single box, but got %v instead", union)
+	}
+	if area := polygonsArea(union); math.Abs(area-2) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected an area of 2, but got %v", area))
+	}
+
+	// Nothing is left of a box after subtracting itself or a box sharing all of its edges but one
+	if difference, _ := a.Difference(boxPolygon(0, 0, 1, 1)); len(difference) != 0 {
+		t.Error("Expected nothing to be left of a box after subtracting itself, but got", difference)
+	}
+	difference, _ := a.Difference(b)
+	if len(difference) != 1 || math.Abs(polygonsArea(difference)-1) > 1e-9 {
+		t.Error("Expected a box to remain after subtracting its neighbour, but got", difference)
+	}
+}
+
+// Ensures that subtracting a polygon from the inside of another one creates a hole.
+func TestDifferenceHole(t *testing.T) {
+	outer := boxPolygon(0, 0, 4, 4)
+	difference, err := outer.Difference(boxPolygon(1, 1, 2, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(difference) != 1 {
+		t.Fatalf("Expected a single polygon, but got %v instead", difference)
+	}
+	if area := polygonsArea(difference); math.Abs(area-15) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected an area of 15, but got %v", area))
+	}
+	if len(difference[0].Points()) != 4 || len(difference[0].Holes()) != 1 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a box with a hole, but got %v", difference[0]))
+	}
+	if !difference[0].Contains(NewPoint(3, 3)) || difference[0].Contains(NewPoint(1.5, 1.5)) {
+		t.Error("Expected the difference to contain [3, 3], but not the hole at [1.5, 1.5]")
+	}
+
+	// Cutting across splits the polygon in two
+	difference, err = outer.Difference(boxPolygon(-1, 1, 5, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(difference) != 2 || math.Abs(polygonsArea(difference)-12) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected two polygons of a total area of 12, but got %v", difference))
+	}
+}
+
+// Ensures that a union with several holes contains exactly the points outside of its holes.
+func TestUnionHoles(t *testing.T) {
+	// The teeth of the comb are closed by the bar, leaving two holes at latitudes 5 to 8
+	comb := NewPolygon([]*Point{
+		NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10), NewPoint(10, 8), NewPoint(5, 8), NewPoint(5, 6),
+		NewPoint(10, 6), NewPoint(10, 4), NewPoint(5, 4), NewPoint(5, 2), NewPoint(10, 2), NewPoint(10, 0),
+	})
+	union, err := comb.Union(boxPolygon(8, -1, 11, 11))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(union) != 1 {
+		t.Fatalf("Expected a single polygon, but got %v instead", union)
+	}
+	if len(union[0].Holes()) != 2 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected two holes, but got %v", union[0].Holes()))
+	}
+	// The comb of 80 and the bar of 36 square degrees overlap by 12
+	if area := polygonsArea(union); math.Abs(area-104) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected an area of 104, but got %v", area))
+	}
+
+	// Sample the centers of a grid of 0.1 degree cells, none of which lies on an edge
+	wrong := 0
+	for lat := -1.95; lat < 12; lat += 0.1 {
+		for lng := -1.95; lng < 12; lng += 0.1 {
+			inHole := lat > 5 && lat < 8 && ((lng > 2 && lng < 4) || (lng > 6 && lng < 8))
+			expected := (lat > 8 && lat < 11 && lng > -1 && lng < 11) || (lat > 0 && lat < 10 && lng > 0 && lng < 10 && !inHole)
+			if union[0].Contains(NewPoint(lat, lng)) != expected {
+				wrong++
+			}
+		}
+	}
+	if wrong > 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the union to contain exactly the expected points, but got %d wrong", wrong))
+	}
+}
+
+// Ensures that slivers below the tolerance are dropped.
+func TestDifferenceSliver(t *testing.T) {
+	a := boxPolygon(0, 0, 1, 1)
+	b := boxPolygon(-1, -1, 2, 1-1e-6)
+
+	difference, err := a.Difference(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(difference) != 1 || math.Abs(polygonsArea(difference)-1e-6) > 1e-12 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a strip of an area of 1e-6, but got %v", difference))
+	}
+
+	difference, err = a.DifferenceWithTolerance(b, 1e-5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(difference) != 0 {
+		t.Error("Expected the strip to be dropped as a sliver, but got", difference)
+	}
+}
+
+// Ensures that operands crossing the antimeridian are rejected.
+func TestUnionAntimeridian(t *testing.T) {
+	crossing := NewPolygon([]*Point{NewPoint(0, 179), NewPoint(0, -179), NewPoint(1, -179), NewPoint(1, 179)})
+	if _, err := crossing.Union(boxPolygon(0, 0, 1, 1)); err != ErrAntimeridianOperand {
+		t.Errorf("Expected ErrAntimeridianOperand, but got %v instead", err)
+	}
+	if _, err := boxPolygon(0, 0, 1, 1).Difference(crossing); err != ErrAntimeridianOperand {
+		t.Errorf("Expected ErrAntimeridianOperand, but got %v instead", err)
+	}
+}
+
+// Returns a random star shaped Polygon with its points rounded to whole degrees,
+// so that many of its points and edges coincide with those of other ones.
+// Rounding may make the Polygon self-intersecting, which is reported as not simple.
+func randomGridPolygon(r *rand.Rand) (polygon *Polygon, simple bool) {
+	n := 3 + r.Intn(6)
+	lat, lng := r.Float64()*4, r.Float64()*4
+	points := []*Point{}
+	for i := 0; i < n; i++ {
+		angle := (float64(i)/float64(n) + r.Float64()*0.08) * 2 * math.Pi
+		radius := 0.5 + r.Float64()*2
+		points = append(points, NewPoint(math.Round(lat+radius*math.Sin(angle)), math.Round(lng+radius*math.Cos(angle))))
+	}
+
+	// Every edge may only touch its neighbours in their common point
+	for i := range points {
+		a1, a2 := points[i], points[(i+1)%n]
+		for j := range points {
+			b1, b2 := points[j], points[(j+1)%n]
+			switch {
+			case i == j:
+			case (j+1)%n == i:
+				if orientation(a1, a2, b1) == 0 && onSegment(a1, a2, b1) {
+					return nil, false
+				}
+			case (i+1)%n == j:
+				if orientation(a1, a2, b2) == 0 && onSegment(a1, a2, b2) {
+					return nil, false
+				}
+			case segmentsIntersect(a1, a2, b1, b2):
+				return nil, false
+			}
+		}
+	}
+	return NewPolygon(points), true
+}
+
+// Ensures that a random sample of points is contained in the union and the difference of
+// random polygons with many shared points and edges exactly if it is contained in the operands.
+func TestUnionDifferenceRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	contains := func(polygons []*Polygon, p *Point) bool {
+		inside := false
+		for _, polygon := range polygons {
+			if polygon.Contains(p) {
+				inside = !inside
+			}
+		}
+		return inside
+	}
+
+	for i := 0; i < 1000; i++ {
+		a, simpleA := randomGridPolygon(r)
+		b, simpleB := randomGridPolygon(r)
+		if !simpleA || !simpleB {
+			continue
+		}
+		union, err := a.Union(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		difference, err := a.Difference(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for j := 0; j < 100; j++ {
+			p := NewPoint(r.Float64()*8-2, r.Float64()*8-2)
+			inA, inB := ringContains(a.Points(), p), ringContains(b.Points(), p)
+			if contains(union, p) != (inA || inB) {
+				t.Fatalf("Expected the union of %v and %v to contain %v: %v", a.Points(), b.Points(), p, inA || inB)
+			}
+			if contains(difference, p) != (inA && !inB) {
+				t.Fatalf("Expected the difference of %v and %v to contain %v: %v", a.Points(), b.Points(), p, inA && !inB)
+			}
+		}
+	}
+}
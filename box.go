@@ -0,0 +1,61 @@
+package geo
+
+import (
+	"math"
+)
+
+// Returns whether or not the box spanned by the south-west and north-east corners sw1 and ne1
+// and the box spanned by sw2 and ne2 overlap.  Boxes touching at an edge or a corner overlap too.
+// If the north-east corner of a box lies west of its south-west corner, the box is considered to cross the antimeridian.
+func BoxesIntersect(sw1, ne1, sw2, ne2 *Point) bool {
+	_, _, ok := BoxIntersection(sw1, ne1, sw2, ne2)
+	return ok
+}
+
+// Returns the south-west and north-east corners of the box where the box spanned by the south-west and north-east
+// corners sw1 and ne1 and the box spanned by sw2 and ne2 overlap, and whether or not they overlap at all.
+// Boxes touching at an edge or a corner overlap in a box without area.
+// If the north-east corner of a box lies west of its south-west corner, the box is considered to cross the antimeridian,
+// and so does the overlap.  Boxes both crossing it can overlap on either side of the globe, in which case the wider
+// of both overlaps is returned.
+func BoxIntersection(sw1, ne1, sw2, ne2 *Point) (sw, ne *Point, ok bool) {
+	minLat, maxLat := math.Max(sw1.lat, sw2.lat), math.Min(ne1.lat, ne2.lat)
+	if minLat > maxLat {
+		return nil, nil, false
+	}
+
+	// Measure both boxes east of the south-west corner of the first one
+	span1, span2 := boxLngSpan(sw1, ne1), boxLngSpan(sw2, ne2)
+	offset := math.Mod(sw2.lng-sw1.lng+360, 360)
+
+	// The second box starts within the first one, or the first one starts within the second one
+	west, width := 0.0, -1.0
+	if offset <= span1 {
+		west, width = offset, math.Min(span1-offset, span2)
+	}
+	if back := math.Mod(360-offset, 360); back <= span2 && math.Min(span2-back, span1) > width {
+		west, width = 0, math.Min(span2-back, span1)
+	}
+	if width < 0 {
+		return nil, nil, false
+	}
+
+	return NewPoint(minLat, boxLng(sw1.lng+west)), NewPoint(maxLat, boxLng(sw1.lng+west+width)), true
+}
+
+// Returns the width (in degrees of longitude) of the box spanned by the passed in south-west and north-east corners.
+func boxLngSpan(sw, ne *Point) float64 {
+	span := ne.lng - sw.lng
+	if span < 0 {
+		span += 360
+	}
+	return span
+}
+
+// Returns the passed in longitude, up to 360 degrees east of the antimeridian, between -180 and 180 degrees.
+func boxLng(lng float64) float64 {
+	if lng > 180 {
+		return lng - 360
+	}
+	return lng
+}
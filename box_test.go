@@ -0,0 +1,49 @@
+package geo
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Ensures that overlapping, touching and disjoint boxes are told apart, also across the antimeridian.
+func TestBoxIntersection(t *testing.T) {
+	tests := []struct {
+		name               string
+		sw1, ne1, sw2, ne2 *Point
+		sw, ne             *Point
+	}{
+		{"overlapping", NewPoint(0, 0), NewPoint(10, 10), NewPoint(5, 5), NewPoint(15, 15), NewPoint(5, 5), NewPoint(10, 10)},
+		{"contained", NewPoint(0, 0), NewPoint(10, 10), NewPoint(2, 3), NewPoint(4, 5), NewPoint(2, 3), NewPoint(4, 5)},
+		{"touching edge", NewPoint(0, 0), NewPoint(10, 10), NewPoint(10, 2), NewPoint(20, 8), NewPoint(10, 2), NewPoint(10, 8)},
+		{"touching corner", NewPoint(0, 0), NewPoint(10, 10), NewPoint(10, 10), NewPoint(20, 20), NewPoint(10, 10), NewPoint(10, 10)},
+		{"disjoint latitudes", NewPoint(0, 0), NewPoint(10, 10), NewPoint(11, 0), NewPoint(20, 10), nil, nil},
+		{"disjoint longitudes", NewPoint(0, 0), NewPoint(10, 10), NewPoint(0, 11), NewPoint(10, 20), nil, nil},
+		// The first box crosses the antimeridian from 170°E to 170°W
+		{"antimeridian east", NewPoint(0, 170), NewPoint(10, -170), NewPoint(5, 175), NewPoint(15, 178), NewPoint(5, 175), NewPoint(10, 178)},
+		{"antimeridian west", NewPoint(0, 170), NewPoint(10, -170), NewPoint(5, -175), NewPoint(15, -160), NewPoint(5, -175), NewPoint(10, -170)},
+		{"antimeridian across", NewPoint(0, 170), NewPoint(10, -170), NewPoint(5, 160), NewPoint(15, -175), NewPoint(5, 170), NewPoint(10, -175)},
+		{"antimeridian both", NewPoint(0, 170), NewPoint(10, -170), NewPoint(5, 175), NewPoint(15, -160), NewPoint(5, 175), NewPoint(10, -170)},
+		{"antimeridian touching", NewPoint(0, 170), NewPoint(10, -170), NewPoint(0, -170), NewPoint(10, -160), NewPoint(0, -170), NewPoint(10, -170)},
+		{"antimeridian disjoint", NewPoint(0, 170), NewPoint(10, -170), NewPoint(0, -20), NewPoint(10, 20), nil, nil},
+		// Wide boxes both crossing the antimeridian overlap on both sides of the globe, the wider overlap wins
+		{"antimeridian twice", NewPoint(0, 10), NewPoint(10, -10), NewPoint(0, 170), NewPoint(10, 20), NewPoint(0, 170), NewPoint(10, -10)},
+	}
+
+	for _, test := range tests {
+		for _, swapped := range []bool{false, true} {
+			sw1, ne1, sw2, ne2 := test.sw1, test.ne1, test.sw2, test.ne2
+			if swapped {
+				sw1, ne1, sw2, ne2 = sw2, ne2, sw1, ne1
+			}
+
+			sw, ne, ok := BoxIntersection(sw1, ne1, sw2, ne2)
+			if ok != (test.sw != nil) || BoxesIntersect(sw1, ne1, sw2, ne2) != ok {
+				t.Error("Unnacceptable result.", fmt.Sprintf("%s: expected the boxes to intersect: %v", test.name, test.sw != nil))
+				continue
+			}
+			if ok && (*sw != *test.sw || *ne != *test.ne) {
+				t.Error("Unnacceptable result.", fmt.Sprintf("%s: expected %v to %v, but got %v to %v", test.name, test.sw, test.ne, sw, ne))
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// The first byte of data rendered by MarshalPointsCompact, identifying the format and its version.
+const compactVersion = 1
+
+// The largest number of decimal digits MarshalPointsCompact keeps.
+const compactMaxPrecision = 15
+
+// Renders the passed in points to a compact byte slice, keeping the passed in number of decimal digits
+// of each coordinate.  The coordinates are rounded to integers at that precision and stored as
+// varint encoded differences to the previous point, so nearby points take only a few bytes each.
+// The data starts with a version byte, the precision and the number of points, so it can be
+// decoded by UnmarshalPointsCompact without further information.
+func MarshalPointsCompact(points []*Point, precision int) ([]byte, error) {
+	if precision < 0 || precision > compactMaxPrecision {
+		return nil, fmt.Errorf("precision %d out of range [0, %d]", precision, compactMaxPrecision)
+	}
+	factor := math.Pow10(precision)
+
+	var scratch [binary.MaxVarintLen64]byte
+	buf := make([]byte, 0, 2+binary.MaxVarintLen64+4*len(points))
+	buf = append(buf, compactVersion, byte(precision))
+	buf = append(buf, scratch[:binary.PutUvarint(scratch[:], uint64(len(points)))]...)
+
+	var prevLat, prevLng int64
+	for _, p := range points {
+		lat, lng := math.Round(p.lat*factor), math.Round(p.lng*factor)
+		if math.Abs(lat) > math.MaxInt64/2 || math.Abs(lng) > math.MaxInt64/2 {
+			return nil, fmt.Errorf("unable to encode %v at precision %d", p, precision)
+		}
+		buf = append(buf, scratch[:binary.PutVarint(scratch[:], int64(lat)-prevLat)]...)
+		buf = append(buf, scratch[:binary.PutVarint(scratch[:], int64(lng)-prevLng)]...)
+		prevLat, prevLng = int64(lat), int64(lng)
+	}
+
+	return buf, nil
+}
+
+// Parses points rendered by MarshalPointsCompact.
+func UnmarshalPointsCompact(data []byte) ([]*Point, error) {
+	if len(data) < 2 || data[0] != compactVersion {
+		return nil, errors.New("Invalid compact points header")
+	}
+	precision := int(data[1])
+	if precision > compactMaxPrecision {
+		return nil, fmt.Errorf("precision %d out of range [0, %d]", precision, compactMaxPrecision)
+	}
+	factor := math.Pow10(precision)
+	data = data[2:]
+
+	count, n := binary.Uvarint(data)
+	// Every point takes at least two bytes
+	if n <= 0 || count > uint64(len(data)-n)/2 {
+		return nil, errors.New("Invalid compact points count")
+	}
+	data = data[n:]
+
+	points := make([]*Point, 0, count)
+	var lat, lng int64
+	for i := uint64(0); i < count; i++ {
+		dLat, n := binary.Varint(data)
+		if n <= 0 {
+			return nil, fmt.Errorf("unable to decode lat of point %d", i)
+		}
+		data = data[n:]
+		dLng, n := binary.Varint(data)
+		if n <= 0 {
+			return nil, fmt.Errorf("unable to decode lng of point %d", i)
+		}
+		data = data[n:]
+
+		lat, lng = lat+dLat, lng+dLng
+		points = append(points, NewPoint(float64(lat)/factor, float64(lng)/factor))
+	}
+
+	if len(data) != 0 {
+		return nil, fmt.Errorf("%d unexpected trailing bytes", len(data))
+	}
+	return points, nil
+}
@@ -0,0 +1,80 @@
+package geo
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Returns a track of n points a few meters apart.
+func compactTrack(n int) []*Point {
+	points := make([]*Point, n)
+	for i := range points {
+		points[i] = NewPoint(47.6062+float64(i)*0.0001*math.Sin(float64(i)/50), -122.3321+float64(i)*0.00013)
+	}
+	return points
+}
+
+// Ensures that points survive the round trip at the chosen precision.
+func TestPointsCompactRoundTrip(t *testing.T) {
+	points := append(compactTrack(100), NewPoint(-90, -180), NewPoint(90, 180), NewPoint(0, 0))
+
+	for _, precision := range []int{0, 5, 7} {
+		data, err := MarshalPointsCompact(points, precision)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		decoded, err := UnmarshalPointsCompact(data)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if len(decoded) != len(points) {
+			t.Fatal("Unnacceptable result.", fmt.Sprintf("Expected %d points, got %d", len(points), len(decoded)))
+		}
+
+		tolerance := 0.5/math.Pow10(precision) + 1e-12
+		for i := range points {
+			if math.Abs(decoded[i].lat-points[i].lat) > tolerance || math.Abs(decoded[i].lng-points[i].lng) > tolerance {
+				t.Error("Unnacceptable result.", fmt.Sprintf("Point %d decoded as %v, expected %v", i, decoded[i], points[i]))
+			}
+		}
+	}
+
+	if decoded, err := UnmarshalPointsCompact([]byte{compactVersion, 6, 0}); err != nil || len(decoded) != 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected no points, got %v, %v", decoded, err))
+	}
+}
+
+// Ensures that the compact format is much smaller than MarshalBinary for a track.
+func TestPointsCompactSize(t *testing.T) {
+	points := compactTrack(1000)
+	data, err := MarshalPointsCompact(points, 6)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	var raw bytes.Buffer
+	for _, p := range points {
+		b, _ := p.MarshalBinary()
+		raw.Write(b)
+	}
+
+	if raw.Len() != 16*len(points) || len(data)*3 > raw.Len() {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected less than a third of %d bytes, got %d", raw.Len(), len(data)))
+	}
+}
+
+// Ensures that invalid data and precisions are rejected.
+func TestPointsCompactInvalid(t *testing.T) {
+	if _, err := MarshalPointsCompact(compactTrack(1), 16); err == nil {
+		t.Error("Expected an error for a precision of 16")
+	}
+
+	data, _ := MarshalPointsCompact(compactTrack(10), 6)
+	for _, invalid := range [][]byte{nil, {2, 6, 0}, {compactVersion, 20, 0}, data[:len(data)-1], append(data, 0), {compactVersion, 6, 100, 0, 0}} {
+		if _, err := UnmarshalPointsCompact(invalid); err == nil {
+			t.Error("Expected an error decoding", invalid)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// A DistanceFunc calculates the distance between two points, e.g. (*Point).GreatCircleDistance.
+type DistanceFunc func(p1, p2 *Point) float64
+
+// The accuracy and speed of a DistanceFunc relative to a reference, as returned by Compare.
+type AccuracyReport struct {
+	// The largest and the mean relative error over all compared pairs
+	MaxRelativeError  float64
+	MeanRelativeError float64
+	// The mean time per call
+	NsPerOp float64
+	// The number of pairs of points compared
+	Pairs int
+}
+
+// The number of pairs of points above which Compare samples pairs.
+const comparePairLimit = 10000
+
+// The seed Compare samples pairs with.
+const compareSeed = 1
+
+// Compares the passed in candidate distance functions against the passed in reference,
+// over all pairs of the passed in points, and returns a report for each candidate.
+// If there are more than 10000 pairs, a sample of 10000 is compared instead,
+// which is the same on every call for the same points.
+// Pairs at a reference distance of 0 are skipped, since their relative error is undefined.
+func Compare(points []*Point, reference DistanceFunc, candidates map[string]DistanceFunc) map[string]AccuracyReport {
+	return CompareWithSeed(points, reference, candidates, compareSeed)
+}
+
+// Same as Compare, but samples pairs using the passed in seed.
+func CompareWithSeed(points []*Point, reference DistanceFunc, candidates map[string]DistanceFunc, seed int64) map[string]AccuracyReport {
+	pairs := comparePairs(points, seed)
+
+	expected := make([]float64, len(pairs))
+	for i, pair := range pairs {
+		expected[i] = reference(pair[0], pair[1])
+	}
+
+	reports := make(map[string]AccuracyReport, len(candidates))
+	for name, candidate := range candidates {
+		report := AccuracyReport{}
+		actual := make([]float64, len(pairs))
+
+		start := time.Now()
+		for i, pair := range pairs {
+			actual[i] = candidate(pair[0], pair[1])
+		}
+		if len(pairs) > 0 {
+			report.NsPerOp = float64(time.Since(start).Nanoseconds()) / float64(len(pairs))
+		}
+
+		sum := 0.0
+		for i := range pairs {
+			if expected[i] == 0 {
+				continue
+			}
+			relative := math.Abs(actual[i]-expected[i]) / math.Abs(expected[i])
+			report.MaxRelativeError = math.Max(report.MaxRelativeError, relative)
+			sum += relative
+			report.Pairs++
+		}
+		if report.Pairs > 0 {
+			report.MeanRelativeError = sum / float64(report.Pairs)
+		}
+
+		reports[name] = report
+	}
+
+	return reports
+}
+
+// Returns all pairs of the passed in points, or a sample of them drawn with the passed in seed.
+func comparePairs(points []*Point, seed int64) [][2]*Point {
+	n := len(points)
+	total := n * (n - 1) / 2
+	if total <= comparePairLimit {
+		pairs := make([][2]*Point, 0, total)
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				pairs = append(pairs, [2]*Point{points[i], points[j]})
+			}
+		}
+		return pairs
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	pairs := make([][2]*Point, 0, comparePairLimit)
+	for len(pairs) < comparePairLimit {
+		i, j := rng.Intn(n), rng.Intn(n)
+		if i != j {
+			pairs = append(pairs, [2]*Point{points[i], points[j]})
+		}
+	}
+	return pairs
+}
@@ -0,0 +1,89 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// A handful of cities around the world.
+var compareCities = []*Point{
+	NewPoint(47.6062, -122.3321), // Seattle
+	NewPoint(40.7128, -74.0060),  // New York
+	NewPoint(51.5074, -0.1278),   // London
+	NewPoint(35.6762, 139.6503),  // Tokyo
+	NewPoint(-33.8688, 151.2093), // Sydney
+	NewPoint(-23.5505, -46.6333), // São Paulo
+	NewPoint(1.3521, 103.8198),   // Singapore
+	NewPoint(64.1466, -21.9426),  // Reykjavík
+	NewPoint(-33.9249, 18.4241),  // Cape Town
+	NewPoint(55.7558, 37.6173),   // Moscow
+}
+
+// The spherical law of cosines distance in sea miles.
+func lawOfCosinesDistance(p1, p2 *Point) float64 {
+	lat1, lat2 := p1.lat*math.Pi/180, p2.lat*math.Pi/180
+	dLng := (p2.lng - p1.lng) * math.Pi / 180
+	c := math.Sin(lat1)*math.Sin(lat2) + math.Cos(lat1)*math.Cos(lat2)*math.Cos(dLng)
+	return math.Acos(math.Max(-1, math.Min(1, c))) * EARTHRADIUS
+}
+
+// The equirectangular approximation of the distance in sea miles.
+func equirectangularDistance(p1, p2 *Point) float64 {
+	dLng := math.Mod(p2.lng-p1.lng+540, 360) - 180
+	x := dLng * math.Pi / 180 * math.Cos((p1.lat+p2.lat)/2*math.Pi/180)
+	y := (p2.lat - p1.lat) * math.Pi / 180
+	return math.Sqrt(x*x+y*y) * EARTHRADIUS
+}
+
+// Ensures that the candidates are ranked by their accuracy against the haversine distance.
+func TestCompare(t *testing.T) {
+	haversine := (*Point).GreatCircleDistance
+	reports := Compare(compareCities, haversine, map[string]DistanceFunc{
+		"haversine":       haversine,
+		"lawOfCosines":    lawOfCosinesDistance,
+		"equirectangular": equirectangularDistance,
+	})
+
+	if len(reports) != 3 {
+		t.Fatalf("Expected 3 reports, but got %v instead", reports)
+	}
+	for name, report := range reports {
+		if report.Pairs != 45 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %s to be compared over 45 pairs, got %d", name, report.Pairs))
+		}
+		if report.MeanRelativeError > report.MaxRelativeError {
+			t.Error("Unnacceptable result.", fmt.Sprintf("%s has a mean error above its max error: %+v", name, report))
+		}
+	}
+
+	if reports["haversine"].MaxRelativeError != 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the reference to match itself, got %+v", reports["haversine"]))
+	}
+	if !(reports["lawOfCosines"].MaxRelativeError < 1e-6) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the law of cosines to be close, got %+v", reports["lawOfCosines"]))
+	}
+	if !(reports["equirectangular"].MeanRelativeError > reports["lawOfCosines"].MeanRelativeError) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected equirectangular to be less accurate, got %+v", reports))
+	}
+}
+
+// Ensures that large inputs are sampled deterministically.
+func TestCompareSampling(t *testing.T) {
+	grid := NewPointGrid(NewPoint(-58, -177), NewPoint(61, 178), 5, 5).Points()
+	candidates := map[string]DistanceFunc{"equirectangular": equirectangularDistance}
+
+	first := Compare(grid, (*Point).GreatCircleDistance, candidates)["equirectangular"]
+	second := Compare(grid, (*Point).GreatCircleDistance, candidates)["equirectangular"]
+	if first.Pairs > comparePairLimit || first.Pairs < comparePairLimit/2 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected about %d sampled pairs, got %d", comparePairLimit, first.Pairs))
+	}
+	if first.MaxRelativeError != second.MaxRelativeError || first.MeanRelativeError != second.MeanRelativeError {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected identical reports, got %+v and %+v", first, second))
+	}
+
+	other := CompareWithSeed(grid, (*Point).GreatCircleDistance, candidates, 2)["equirectangular"]
+	if other.MeanRelativeError == first.MeanRelativeError {
+		t.Error("Unnacceptable result.", "Expected a different seed to sample different pairs")
+	}
+}
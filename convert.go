@@ -0,0 +1,99 @@
+package geo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Options for NewFormatConverter.
+type ConvertOptions struct {
+	// Copies lines which can not be parsed to the output unchanged,
+	// instead of failing with the number of the offending line.
+	PassThrough bool
+	// The decimal places the output is rendered with, DefaultPrecision if nil
+	Precision *PrecisionPolicy
+}
+
+// Reformats the lines read from an io.Reader, see NewFormatConverter.
+type formatConverter struct {
+	scanner   *bufio.Scanner
+	parser    Parser
+	inFormat  Format
+	outFormat Format
+	opts      ConvertOptions
+
+	line int
+	buf  []byte
+	err  error
+}
+
+// Returns an io.Reader streaming the line delimited coordinates read from the passed in io.Reader,
+// parsed in the passed in input format and reformatted in the passed in output format.
+// Blank lines are kept as they are.  The lines are converted one by one as the returned
+// io.Reader is read, so memory usage does not depend on the size of the input.
+// Unless ConvertOptions.PassThrough is set, reading fails at the first line which can not be parsed,
+// with an error wrapping that of the parser, e.g. ErrPossiblySwapped.
+func NewFormatConverter(r io.Reader, inFormat, outFormat Format, opts ConvertOptions) io.Reader {
+	return &formatConverter{
+		scanner:   bufio.NewScanner(r),
+		inFormat:  inFormat,
+		outFormat: outFormat,
+		opts:      opts,
+	}
+}
+
+func (c *formatConverter) Read(p []byte) (int, error) {
+	for len(c.buf) == 0 && c.err == nil {
+		c.next()
+	}
+
+	n := copy(p, c.buf)
+	c.buf = c.buf[n:]
+	if len(c.buf) == 0 && c.err != nil {
+		return n, c.err
+	}
+	return n, nil
+}
+
+// Converts the next line into the buffer, or records why there is none.
+func (c *formatConverter) next() {
+	if !c.scanner.Scan() {
+		c.err = c.scanner.Err()
+		if c.err == nil {
+			c.err = io.EOF
+		}
+		return
+	}
+	c.line++
+
+	line := strings.TrimRight(c.scanner.Text(), "\r")
+	if strings.TrimSpace(line) == "" {
+		c.buf = append(c.buf[:0], line...)
+		c.buf = append(c.buf, '\n')
+		return
+	}
+
+	converted, err := c.convert(line)
+	if err != nil {
+		if !c.opts.PassThrough {
+			c.err = fmt.Errorf("Unable to convert line %d: %w", c.line, err)
+			return
+		}
+		converted = line
+	}
+	c.buf = append(c.buf[:0], converted...)
+	c.buf = append(c.buf, '\n')
+}
+
+func (c *formatConverter) convert(line string) (string, error) {
+	p, err := c.parser.parseFormat(line, c.inFormat)
+	if err != nil {
+		return "", err
+	}
+	if c.opts.Precision != nil {
+		return c.opts.Precision.Format(p, c.outFormat)
+	}
+	return p.Format(c.outFormat)
+}
@@ -0,0 +1,142 @@
+package geo
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strings"
+	"testing"
+)
+
+// Returns a fixture of n lines of coordinates in the decimal seconds format,
+// together with the values they represent.
+func decimalSecondsFixture(n int) (string, []*Point) {
+	var b strings.Builder
+	points := make([]*Point, n)
+	for i := 0; i < n; i++ {
+		latd, latm, lats := i%90, (i*7)%60, float64((i*13)%60000)/1000
+		lngd, lngm, lngs := (i*3)%180, (i*11)%60, float64((i*17)%60000)/1000
+		ns, ew, latSign, lngSign := "N", "E", 1.0, 1.0
+		if i%2 == 1 {
+			ns, latSign = "S", -1
+		}
+		if i%3 == 1 {
+			ew, lngSign = "W", -1
+		}
+		fmt.Fprintf(&b, "%s %d %d %.3f, %s %d %d %.3f\n", ns, latd, latm, lats, ew, lngd, lngm, lngs)
+		points[i] = NewPoint(
+			latSign*(float64(latd)+float64(latm)/60+lats/3600),
+			lngSign*(float64(lngd)+float64(lngm)/60+lngs/3600))
+	}
+	return b.String(), points
+}
+
+// Ensures that a large fixture is converted from decimal seconds to decimal degrees.
+func TestFormatConverter(t *testing.T) {
+	fixture, points := decimalSecondsFixture(10000)
+
+	out, err := ioutil.ReadAll(NewFormatConverter(strings.NewReader(fixture), DecimalSeconds, DecimalDegrees, ConvertOptions{}))
+	if err != nil {
+		t.Fatal("Conversion failed:", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	i := 0
+	for ; scanner.Scan(); i++ {
+		p, err := Parse(scanner.Text())
+		if err != nil {
+			t.Fatal("Unable to parse converted line", i+1, err)
+		}
+		// Decimal degrees are formatted with 6 decimals
+		if math.Abs(p.Lat()-points[i].Lat()) > 1e-6 || math.Abs(p.Lng()-points[i].Lng()) > 1e-6 {
+			t.Fatal("Unnacceptable result.", fmt.Sprintf("Line %d converted to %v, expected %v", i+1, p, points[i]))
+		}
+	}
+	if i != len(points) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %d lines, got %d", len(points), i))
+	}
+
+	// And back: the decimal degrees survive a round trip through decimal seconds,
+	// which are rounded to thousandths of a second
+	seconds, err := ioutil.ReadAll(NewFormatConverter(bytes.NewReader(out), DecimalDegrees, DecimalSeconds, ConvertOptions{}))
+	if err != nil {
+		t.Fatal("Conversion failed:", err)
+	}
+	degrees, err := ioutil.ReadAll(NewFormatConverter(bytes.NewReader(seconds), DecimalSeconds, DecimalDegrees, ConvertOptions{}))
+	if err != nil {
+		t.Fatal("Conversion failed:", err)
+	}
+	original := bufio.NewScanner(bytes.NewReader(out))
+	scanner = bufio.NewScanner(bytes.NewReader(degrees))
+	i = 0
+	for ; scanner.Scan() && original.Scan(); i++ {
+		p, err := Parse(scanner.Text())
+		if err != nil {
+			t.Fatal("Unable to parse converted line", i+1, err)
+		}
+		expected, _ := Parse(original.Text())
+		if math.Abs(p.Lat()-expected.Lat()) > 1.5e-6 || math.Abs(p.Lng()-expected.Lng()) > 1.5e-6 {
+			t.Fatal("Unnacceptable result.", fmt.Sprintf("Line %d round tripped from %s to %s", i+1, original.Text(), scanner.Text()))
+		}
+	}
+	if i != len(points) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %d lines after the round trip, got %d", len(points), i))
+	}
+}
+
+// Ensures that unparseable lines either fail with their line number or are passed through.
+func TestFormatConverterInvalidLines(t *testing.T) {
+	input := "40.5, -120.5\n\nnot a coordinate\n-10, 20\n"
+
+	_, err := ioutil.ReadAll(NewFormatConverter(strings.NewReader(input), DecimalDegrees, DecimalMinutes, ConvertOptions{}))
+	if err == nil || !strings.HasPrefix(err.Error(), "Unable to convert line 3: ") {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected an error for line 3, got %v", err))
+	}
+
+	// The errors of the parser are wrapped, so possibly swapped coordinates can be told apart
+	for _, swapped := range []string{"40.5, -120.5\n95, 20\n", "40.5, -120.5\n-122.3, 47.6\n"} {
+		_, err = ioutil.ReadAll(NewFormatConverter(strings.NewReader(swapped), DecimalDegrees, DecimalMinutes, ConvertOptions{}))
+		if !errors.Is(err, ErrPossiblySwapped) || !errors.Is(err, ErrOutOfRange) || !strings.HasPrefix(err.Error(), "Unable to convert line 2: ") {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected an error wrapping ErrPossiblySwapped for line 2, got %v", err))
+		}
+	}
+
+	out, err := ioutil.ReadAll(NewFormatConverter(strings.NewReader(input), DecimalDegrees, DecimalMinutes, ConvertOptions{PassThrough: true}))
+	if err != nil {
+		t.Fatal("Conversion failed:", err)
+	}
+	expected := "N 40 30.000, W 120 30.000\n\nnot a coordinate\nS 10 0.000, E 20 0.000\n"
+	if string(out) != expected {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %q, got %q", expected, out))
+	}
+
+	// Values in a different format than the input format are not parsed
+	_, err = ioutil.ReadAll(NewFormatConverter(strings.NewReader("N 40 30, W 120 30\n"), DecimalDegrees, DecimalMinutes, ConvertOptions{}))
+	if err == nil {
+		t.Error("Expected an error converting decimal minutes as decimal degrees")
+	}
+}
+
+// Ensures that the converter works with small reads.
+func TestFormatConverterSmallReads(t *testing.T) {
+	r := NewFormatConverter(strings.NewReader("1,2\n3,4"), DecimalDegrees, DecimalDegrees, ConvertOptions{})
+	var out []byte
+	buf := make([]byte, 3)
+	for {
+		n, err := r.Read(buf)
+		out = append(out, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal("Conversion failed:", err)
+		}
+	}
+	if string(out) != "1.000000,2.000000\n3.000000,4.000000\n" {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%q", out))
+	}
+}
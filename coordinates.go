@@ -0,0 +1,56 @@
+package geo
+
+// Coordinates are the latitude and longitude of a Point as a plain value type with exported fields,
+// which can be written as a composite literal and be used as a struct field without a pointer.
+// They are rendered to and decoded from JSON and binary exactly like a Point.
+//
+// To keep coordinates in a struct which is marshaled, use a named Coordinates field.
+// A *Point field works too, but a Point value field is not rendered by json.Marshal,
+// as the marshaling methods of Point have pointer receivers.  Do not embed Coordinates
+// anonymously, as its marshaling methods would then be promoted to the embedding struct.
+type Coordinates struct {
+	Lat float64
+	Lng float64
+}
+
+// Returns the coordinates of the current Point.
+func (p *Point) ToCoordinates() Coordinates {
+	return Coordinates{Lat: p.lat, Lng: p.lng}
+}
+
+// Creates and returns a new pointer to a Point at the passed in coordinates.
+func FromCoordinates(c Coordinates) *Point {
+	return NewPoint(c.Lat, c.Lng)
+}
+
+// Renders the coordinates to JSON like Point.MarshalJSON.
+// Implements the json.Marshaller Interface.
+func (c Coordinates) MarshalJSON() ([]byte, error) {
+	return FromCoordinates(c).MarshalJSON()
+}
+
+// Decodes the coordinates from JSON like Point.UnmarshalJSON.
+func (c *Coordinates) UnmarshalJSON(data []byte) error {
+	var p Point
+	if err := p.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*c = p.ToCoordinates()
+	return nil
+}
+
+// Renders the coordinates to a byte slice like Point.MarshalBinary.
+// Implements the encoding.BinaryMarshaler Interface.
+func (c Coordinates) MarshalBinary() ([]byte, error) {
+	return FromCoordinates(c).MarshalBinary()
+}
+
+// Decodes the coordinates from a byte slice like Point.UnmarshalBinary.
+func (c *Coordinates) UnmarshalBinary(data []byte) error {
+	var p Point
+	if err := p.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	*c = p.ToCoordinates()
+	return nil
+}
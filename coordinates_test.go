@@ -0,0 +1,62 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// A struct keeping coordinates in a value field, as recommended.
+type testPlace struct {
+	Name     string
+	Position Coordinates
+}
+
+// Ensures that a struct with a Coordinates field round trips through JSON without custom code.
+func TestCoordinatesJSON(t *testing.T) {
+	place := testPlace{Name: "Seattle", Position: Coordinates{Lat: 47.6062, Lng: -122.3321}}
+
+	data, err := json.Marshal(place)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	expected := `{"Name":"Seattle","Position":{"lat":47.6062,"lng":-122.3321}}`
+	if string(data) != expected {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %s, got %s", expected, data))
+	}
+
+	var decoded testPlace
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if decoded != place {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %+v, got %+v", place, decoded))
+	}
+
+	// Coordinates and Points are interchangeable in JSON
+	pointJSON, _ := json.Marshal(FromCoordinates(place.Position))
+	coordinatesJSON, _ := json.Marshal(place.Position)
+	if string(pointJSON) != string(coordinatesJSON) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %s, got %s", pointJSON, coordinatesJSON))
+	}
+}
+
+// Ensures that Coordinates and Points convert and marshal to binary alike.
+func TestCoordinatesBinary(t *testing.T) {
+	p := NewPoint(-33.8688, 151.2093)
+	c := p.ToCoordinates()
+	if c.Lat != p.Lat() || c.Lng != p.Lng() {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Converted %v to %+v", p, c))
+	}
+
+	pointData, _ := p.MarshalBinary()
+	data, err := c.MarshalBinary()
+	if err != nil || string(data) != string(pointData) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %x, got %x (%v)", pointData, data, err))
+	}
+
+	var decoded Coordinates
+	if err := decoded.UnmarshalBinary(data); err != nil || decoded != c {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %+v, got %+v (%v)", c, decoded, err))
+	}
+}
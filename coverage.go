@@ -0,0 +1,65 @@
+package geo
+
+import (
+	"math"
+)
+
+// The number of rows and columns of cells sampled per circle by CoverageArea.
+const coverageSamples = 400
+
+// Calculates the area (in square sea miles) covered by the union of the circles with the passed
+// in centers and a common radius (in sea miles), e.g. the coverage of a set of cell towers.
+// Each circle is approximated by a Polygon of the passed in number of vertices, and the
+// bounding box of each Polygon is divided into a grid of cells whose centers are tested against the Polygons.
+// A cell counts towards a circle only if its center is not covered by any earlier circle,
+// so that overlapping areas are counted once.
+//
+// The polygon approximation underestimates the area of each circle by a factor of
+// sin(2π/segments)·segments/2π (about 0.16% for 64 segments), and the sampling adds an error
+// proportional to the length of the boundaries, well below 1% of the area for each circle.
+// Circles must neither contain a pole nor cross the antimeridian.
+func CoverageArea(centers []*Point, radius float64, segments int) float64 {
+	circles := make([]*Polygon, len(centers))
+	for i, center := range centers {
+		circles[i] = circlePolygon(center, radius, segments)
+	}
+
+	area := 0.0
+	for i, circle := range circles {
+		minLat, minLng, maxLat, maxLng := circle.bounds()
+		dLat := (maxLat - minLat) / coverageSamples
+		dLng := (maxLng - minLng) / coverageSamples
+
+		for row := 0; row < coverageSamples; row++ {
+			lat1 := minLat + float64(row)*dLat
+			lat2 := lat1 + dLat
+
+			// Exact area of a cell between two parallels and two meridians
+			cellArea := EARTHRADIUS * EARTHRADIUS * dLng * math.Pi / 180.0 *
+				math.Abs(math.Sin(lat2*math.Pi/180.0)-math.Sin(lat1*math.Pi/180.0))
+
+			for col := 0; col < coverageSamples; col++ {
+				sample := NewPoint((lat1+lat2)/2, minLng+(float64(col)+0.5)*dLng)
+				if circle.Contains(sample) && !coveredBefore(circles[:i], centers[:i], sample, radius) {
+					area += cellArea
+				}
+			}
+		}
+	}
+
+	return area
+}
+
+// Returns whether or not the passed in point is covered by any of the circles.
+func coveredBefore(circles []*Polygon, centers []*Point, point *Point, radius float64) bool {
+	for j, circle := range circles {
+		// Cheap rejection of circles that are too far away
+		if centers[j].GreatCircleDistance(point) > radius {
+			continue
+		}
+		if circle.Contains(point) {
+			return true
+		}
+	}
+	return false
+}
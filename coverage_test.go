@@ -0,0 +1,39 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Exact area of a spherical cap with the passed in radius in square sea miles.
+func capArea(radius float64) float64 {
+	return 2 * math.Pi * EARTHRADIUS * EARTHRADIUS * (1 - math.Cos(radius/EARTHRADIUS))
+}
+
+// Ensures that two fully overlapping circles cover the area of one circle.
+func TestCoverageAreaOverlapping(t *testing.T) {
+	center := NewPoint(47.6, -122.3)
+	expected := capArea(10)
+
+	area := CoverageArea([]*Point{center, NewPoint(center.lat, center.lng)}, 10, 64)
+	if math.Abs(area-expected)/expected > 0.01 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%f, expected %f", area, expected))
+	}
+}
+
+// Ensures that two disjoint circles cover the sum of their areas.
+func TestCoverageAreaDisjoint(t *testing.T) {
+	expected := 2 * capArea(10)
+
+	area := CoverageArea([]*Point{NewPoint(47.6, -122.3), NewPoint(0, 10)}, 10, 64)
+	if math.Abs(area-expected)/expected > 0.01 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%f, expected %f", area, expected))
+	}
+
+	// Two circles touching each other overlap hardly at all
+	area = CoverageArea([]*Point{NewPoint(0, 0), NewPoint(0, 0).PointAtDistanceAndBearing(20, 90)}, 10, 64)
+	if math.Abs(area-expected)/expected > 0.01 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%f, expected %f", area, expected))
+	}
+}
@@ -0,0 +1,66 @@
+package geo
+
+import (
+	"math"
+	"strconv"
+)
+
+// The names of the 16 points of the compass, clockwise from north.
+var compassPoints = []string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+// Returns the name of the point of the 16 wind compass rose
+// (N, NNE, NE, ... NNW) closest to the passed in bearing (in degrees).
+func CompassPoint(bearing float64) string {
+	bearing = math.Mod(math.Mod(bearing, 360.)+360., 360.)
+	return compassPoints[int(math.Floor(bearing/22.5+0.5))%len(compassPoints)]
+}
+
+// A Change describes the movement from one location to another.
+type Change struct {
+	// Great circle distance moved in meters
+	DistanceM float64
+	// Initial bearing of the movement in degrees
+	Bearing float64
+	// Point of the compass closest to the bearing, e.g. "NNE"
+	Compass string
+}
+
+// Returns the Change from the old to the new location.
+func NewChange(old, new *Point) Change {
+	bearing := old.BearingTo(new)
+	return Change{
+		DistanceM: old.GreatCircleDistance(new) * metersPerSeaMile,
+		Bearing:   bearing,
+		Compass:   CompassPoint(bearing),
+	}
+}
+
+// DescribeOptions configures DescribeChange.
+type DescribeOptions struct {
+	// Movements shorter than this many meters are considered noise
+	// and described as "no change".
+	NoiseFloorM float64
+}
+
+// Describes the movement from the old to the new location in words, e.g. "moved 340 m NNE".
+// Distances under 1 km are given in whole meters, longer ones in kilometers with one decimal,
+// always with a decimal point regardless of locale.
+// Movements shorter than the noise floor are described as "no change".
+func DescribeChange(old, new *Point, opts DescribeOptions) string {
+	change := NewChange(old, new)
+	if change.DistanceM == 0 || change.DistanceM < opts.NoiseFloorM {
+		return "no change"
+	}
+
+	// Round first so that e.g. 999.6 m is described as 1.0 km
+	meters := math.Floor(change.DistanceM + 0.5)
+	distance := strconv.FormatFloat(meters, 'f', 0, 64) + " m"
+	if meters >= 1000 {
+		distance = strconv.FormatFloat(change.DistanceM/1000, 'f', 1, 64) + " km"
+	}
+
+	return "moved " + distance + " " + change.Compass
+}
@@ -0,0 +1,77 @@
+package geo
+
+import (
+	"testing"
+)
+
+// Tests that bearings are named after the closest point of the compass
+func TestCompassPoint(t *testing.T) {
+	var compasstests = []struct {
+		in  float64
+		out string
+	}{
+		{0, "N"},
+		{11.2, "N"},
+		{11.3, "NNE"},
+		{22.5, "NNE"},
+		{45, "NE"},
+		{90, "E"},
+		{180, "S"},
+		{270, "W"},
+		{348.7, "NNW"},
+		{355, "N"},
+		{360, "N"},
+		{-90, "W"},
+	}
+
+	for _, tt := range compasstests {
+		if compass := CompassPoint(tt.in); compass != tt.out {
+			t.Errorf("Expected bearing %f to be named '%s', but got '%s' instead", tt.in, tt.out, compass)
+		}
+	}
+}
+
+// Tests that movements are described in words
+func TestDescribeChange(t *testing.T) {
+	origin := NewPoint(47.6, -122.3)
+	meters := func(m float64) float64 {
+		return m / metersPerSeaMile
+	}
+
+	var describetests = []struct {
+		to   *Point
+		opts DescribeOptions
+		out  string
+	}{
+		{origin.PointAtDistanceAndBearing(meters(340), 22.5), DescribeOptions{}, "moved 340 m NNE"},
+		{origin.PointAtDistanceAndBearing(meters(12), 180), DescribeOptions{}, "moved 12 m S"},
+		{origin.PointAtDistanceAndBearing(meters(999.6), 270), DescribeOptions{}, "moved 1.0 km W"},
+		{origin.PointAtDistanceAndBearing(meters(1500), 90), DescribeOptions{}, "moved 1.5 km E"},
+		{origin.PointAtDistanceAndBearing(meters(25340), 315), DescribeOptions{}, "moved 25.3 km NW"},
+		{origin.PointAtDistanceAndBearing(meters(0.4), 45), DescribeOptions{}, "moved 0 m NE"},
+		{origin.PointAtDistanceAndBearing(meters(0.4), 45), DescribeOptions{NoiseFloorM: 1}, "no change"},
+		{NewPoint(origin.lat, origin.lng), DescribeOptions{}, "no change"},
+	}
+
+	for _, tt := range describetests {
+		if description := DescribeChange(origin, tt.to, tt.opts); description != tt.out {
+			t.Errorf("Expected the movement to %v to be described as '%s', but got '%s' instead", tt.to, tt.out, description)
+		}
+	}
+}
+
+// Tests that the structured change carries distance, bearing and compass point
+func TestNewChange(t *testing.T) {
+	origin := NewPoint(47.6, -122.3)
+	change := NewChange(origin, origin.PointAtDistanceAndBearing(1, 100))
+
+	if change.DistanceM < 1851.9 || change.DistanceM > 1852.1 {
+		t.Errorf("Expected the change to be 1852 meters, but got %f instead", change.DistanceM)
+	}
+	if change.Bearing < 99.99 || change.Bearing > 100.01 {
+		t.Errorf("Expected the bearing to be 100 degrees, but got %f instead", change.Bearing)
+	}
+	if change.Compass != "E" {
+		t.Errorf("Expected the compass point to be 'E', but got '%s' instead", change.Compass)
+	}
+}
@@ -0,0 +1,95 @@
+package geo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A Distance is a length in sea miles, the unit used throughout the package.
+type Distance float64
+
+type Unit int
+
+const (
+	// Sea miles, e.g. 2nm
+	NauticalMiles Unit = iota
+	// Kilometers, e.g. 5km
+	Kilometers
+	// Meters, e.g. 300m
+	Meters
+	// Statute miles, e.g. 2mi
+	Miles
+	// International feet, e.g. 500ft
+	Feet
+)
+
+// The suffixes of the units, as returned by Unit.String.
+var unitSuffixes = []string{"nm", "km", "m", "mi", "ft"}
+
+// The length of each unit in meters.
+var unitMeters = []float64{metersPerSeaMile, 1000, 1, 1609.344, 0.3048}
+
+// Returns the suffix of the unit, e.g. "km".
+func (u Unit) String() string {
+	if u < 0 || int(u) >= len(unitSuffixes) {
+		return "Unit(" + strconv.Itoa(int(u)) + ")"
+	}
+	return unitSuffixes[u]
+}
+
+// Returns the length of the passed in unit in meters.
+// Panics if the unit is not one of the Unit constants.
+func (u Unit) meters() float64 {
+	if u < 0 || int(u) >= len(unitMeters) {
+		panic("geo: invalid unit " + u.String())
+	}
+	return unitMeters[u]
+}
+
+// Returns the Distance of the passed in value in the passed in unit.
+// Panics if the unit is not one of the Unit constants.
+func NewDistance(value float64, unit Unit) Distance {
+	return Distance(value * unit.meters() / metersPerSeaMile)
+}
+
+// Parses a distance made up of a non-negative decimal value followed by a unit suffix,
+// optionally separated by whitespace, e.g. "5km", "300 m", "2.5mi", "1nm" or "500ft".
+// The suffixes are case insensitive.
+func ParseDistance(s string) (Distance, error) {
+	value := strings.TrimSpace(s)
+	i := strings.IndexFunc(value, unicode.IsLetter)
+	if i < 0 {
+		return 0, fmt.Errorf("Missing unit in distance %q, valid units are %s", s, strings.Join(unitSuffixes, ", "))
+	}
+
+	number, suffix := strings.TrimSpace(value[:i]), strings.ToLower(value[i:])
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid value %q in distance %q", number, s)
+	}
+	if f < 0 {
+		return 0, fmt.Errorf("Negative value %q in distance %q", number, s)
+	}
+
+	for u, unitSuffix := range unitSuffixes {
+		if suffix == unitSuffix {
+			return NewDistance(f, Unit(u)), nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown unit %q in distance %q, valid units are %s", value[i:], s, strings.Join(unitSuffixes, ", "))
+}
+
+// Returns the distance in the passed in unit.
+// Panics if the unit is not one of the Unit constants.
+func (d Distance) In(unit Unit) float64 {
+	return float64(d) * metersPerSeaMile / unit.meters()
+}
+
+// Renders the distance in the passed in unit with the passed in number of decimal places, e.g. "5.00km".
+// The result can be parsed again with ParseDistance.
+// Panics if the unit is not one of the Unit constants.
+func (d Distance) Format(unit Unit, precision int) string {
+	return strconv.FormatFloat(d.In(unit), 'f', precision, 64) + unit.String()
+}
@@ -0,0 +1,116 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// Ensures that distances in all units are parsed.
+func TestParseDistance(t *testing.T) {
+	tests := []struct {
+		value  string
+		meters float64
+	}{
+		{"5km", 5000},
+		{"300m", 300},
+		{"2mi", 3218.688},
+		{"1nm", 1852},
+		{"500ft", 152.4},
+		{"2.5 km", 2500},
+		{"  0.5NM ", 926},
+		{"0m", 0},
+		{".25Mi", 402.336},
+	}
+
+	for _, test := range tests {
+		d, err := ParseDistance(test.value)
+		if err != nil {
+			t.Error("Unable to parse", test.value, err)
+			continue
+		}
+		if math.Abs(d.In(Meters)-test.meters) > 1e-9 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Parsed %q as %vm, expected %vm", test.value, d.In(Meters), test.meters))
+		}
+	}
+}
+
+// Ensures that malformed distances are rejected, naming the offending token.
+func TestParseDistanceErrors(t *testing.T) {
+	tests := []struct {
+		value string
+		token string
+	}{
+		{"-5km", `"-5"`},
+		{"5 furlongs", `"furlongs"`},
+		{"5kms", `"kms"`},
+		{"km", `""`},
+		{"1.2.3m", `"1.2.3"`},
+		{"5", `"5"`},
+		{"", `""`},
+	}
+
+	for _, test := range tests {
+		_, err := ParseDistance(test.value)
+		if err == nil {
+			t.Error("Expected an error parsing", test.value)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.token) {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected the error for %q to name %s, but got %v", test.value, test.token, err))
+		}
+	}
+}
+
+// Ensures that distances are formatted in all units and can be parsed again.
+func TestDistanceFormat(t *testing.T) {
+	d := NewDistance(5, Kilometers)
+	tests := []struct {
+		unit      Unit
+		precision int
+		expected  string
+	}{
+		{Kilometers, 2, "5.00km"},
+		{Meters, 0, "5000m"},
+		{Miles, 3, "3.107mi"},
+		{NauticalMiles, 1, "2.7nm"},
+		{Feet, 1, "16404.2ft"},
+	}
+
+	for _, test := range tests {
+		s := d.Format(test.unit, test.precision)
+		if s != test.expected {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Formatted 5km in %v as %q, expected %q", test.unit, s, test.expected))
+		}
+		if _, err := ParseDistance(s); err != nil {
+			t.Error("Unable to parse", s, err)
+		}
+	}
+
+	if float64(NewDistance(1852, Meters)) != 1 {
+		t.Error("Expected a Distance to be measured in sea miles")
+	}
+}
+
+// Ensures that invalid units panic with a message naming them, rather than with an index out of range.
+func TestInvalidUnitPanics(t *testing.T) {
+	p := NewPoint(40.7486, -73.9864)
+	calls := map[string]func(){
+		"NewDistance":                 func() { NewDistance(1, Unit(5)) },
+		"In":                          func() { Distance(1).In(Unit(-1)) },
+		"Format":                      func() { Distance(1).Format(Unit(42), 2) },
+		"GreatCircleDistanceIn":       func() { p.GreatCircleDistanceIn(p, Unit(5)) },
+		"PointAtDistanceAndBearingIn": func() { p.PointAtDistanceAndBearingIn(1, 90, Unit(5)) },
+	}
+	for name, call := range calls {
+		func() {
+			defer func() {
+				if r := recover(); r == nil || !strings.HasPrefix(fmt.Sprint(r), "geo: invalid unit Unit(") {
+					t.Error("Unnacceptable result.", fmt.Sprintf("Expected %s to panic for an invalid unit, but got %v", name, r))
+				}
+			}()
+			call()
+		}()
+	}
+}
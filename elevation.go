@@ -0,0 +1,226 @@
+package geo
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// An ElevationProvider looks up the elevations (in meters) of points.
+type ElevationProvider interface {
+	// Returns the elevations of the passed in points, in the same order.
+	Elevations(ctx context.Context, points []*Point) ([]float64, error)
+}
+
+// The value SRTM uses for samples without data.
+const srtmVoid = -32768
+
+// A MissingTileError is returned by an SRTMProvider asked for a point
+// whose tile is not available.
+type MissingTileError struct {
+	// The name of the tile, e.g. "N47W123.hgt"
+	Tile string
+}
+
+func (e *MissingTileError) Error() string {
+	return "missing SRTM tile " + e.Tile
+}
+
+// Returned by an SRTMProvider for points surrounded by samples without data.
+var ErrVoidElevation = errors.New("no elevation data at point")
+
+// An SRTMProvider reads elevations from SRTM .hgt tiles in a directory,
+// interpolating bilinearly between samples.  Tiles are loaded on first use and kept in memory.
+// It is safe for concurrent use.
+type SRTMProvider struct {
+	dir string
+
+	mu    sync.Mutex
+	tiles map[string]*hgtTile
+}
+
+// The samples of a single .hgt tile, covering one degree of latitude and longitude.
+type hgtTile struct {
+	// The number of samples along each side, 3601 for 1 arc second tiles
+	size    int
+	samples []int16
+}
+
+// Creates and returns a pointer to a new SRTMProvider reading the tiles in the passed in directory.
+// Tiles are named after their south-west corner, e.g. "N47W123.hgt".
+func NewSRTMProvider(dir string) *SRTMProvider {
+	return &SRTMProvider{dir: dir, tiles: map[string]*hgtTile{}}
+}
+
+// Returns the elevations of the passed in points, or a *MissingTileError naming the first missing tile.
+func (s *SRTMProvider) Elevations(ctx context.Context, points []*Point) ([]float64, error) {
+	elevations := make([]float64, len(points))
+	for i, p := range points {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		lat, lng := math.Floor(p.lat), math.Floor(p.lng)
+		tile, err := s.tile(hgtTileName(lat, lng))
+		if err != nil {
+			return nil, err
+		}
+		if elevations[i], err = tile.interpolate(lat+1-p.lat, p.lng-lng); err != nil {
+			return nil, err
+		}
+	}
+	return elevations, nil
+}
+
+// Returns the name of the tile with the passed in south-west corner.
+func hgtTileName(lat, lng float64) string {
+	ns, ew := "N", "E"
+	if lat < 0 {
+		ns = "S"
+	}
+	if lng < 0 {
+		ew = "W"
+	}
+	return fmt.Sprintf("%s%02d%s%03d.hgt", ns, int(math.Abs(lat)), ew, int(math.Abs(lng)))
+}
+
+// Returns the tile with the passed in name, loading it if needed.
+func (s *SRTMProvider) tile(name string) (*hgtTile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tile, ok := s.tiles[name]; ok {
+		return tile, nil
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, name))
+	if os.IsNotExist(err) {
+		return nil, &MissingTileError{Tile: name}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Tiles are square grids of big endian 16 bit samples, row by row from the north-west corner
+	size := int(math.Sqrt(float64(len(data) / 2)))
+	if size < 2 || size*size*2 != len(data) {
+		return nil, fmt.Errorf("invalid SRTM tile %s of %d bytes", name, len(data))
+	}
+	tile := &hgtTile{size: size, samples: make([]int16, size*size)}
+	for i := range tile.samples {
+		tile.samples[i] = int16(binary.BigEndian.Uint16(data[2*i:]))
+	}
+
+	s.tiles[name] = tile
+	return tile, nil
+}
+
+// Returns the elevation at the passed in offsets from the north-west corner of the tile (in degrees),
+// interpolated bilinearly from the surrounding samples which have data.
+func (t *hgtTile) interpolate(south, east float64) (float64, error) {
+	row := south * float64(t.size-1)
+	col := east * float64(t.size-1)
+	r0 := int(math.Min(math.Floor(row), float64(t.size-2)))
+	c0 := int(math.Min(math.Floor(col), float64(t.size-2)))
+	fr, fc := row-float64(r0), col-float64(c0)
+
+	elevation, weights := 0.0, 0.0
+	for _, corner := range [4]struct {
+		r, c   int
+		weight float64
+	}{
+		{r0, c0, (1 - fr) * (1 - fc)},
+		{r0, c0 + 1, (1 - fr) * fc},
+		{r0 + 1, c0, fr * (1 - fc)},
+		{r0 + 1, c0 + 1, fr * fc},
+	} {
+		sample := t.samples[corner.r*t.size+corner.c]
+		if sample == srtmVoid || corner.weight == 0 {
+			continue
+		}
+		elevation += float64(sample) * corner.weight
+		weights += corner.weight
+	}
+
+	if weights == 0 {
+		return 0, ErrVoidElevation
+	}
+	return elevation / weights, nil
+}
+
+// A single sample of a Profile.
+type ProfileSample struct {
+	Point *Point
+	// The distance from the start of the path (in meters)
+	Distance float64
+	// The elevation (in meters)
+	Elevation float64
+}
+
+// The elevation profile of a path, as returned by PathElevationProfile.
+type Profile struct {
+	// The total climb and descent along the path (in meters)
+	Gain float64
+	Loss float64
+	// The lowest and highest sampled elevations (in meters)
+	Min float64
+	Max float64
+
+	Samples []ProfileSample
+}
+
+// Samples the elevation along the passed in path at least every sampleMeters meters,
+// following each leg along the great circle, and returns the resulting Profile.
+// The points of the path are always sampled themselves.
+func PathElevationProfile(ctx context.Context, provider ElevationProvider, path []*Point, sampleMeters float64) (Profile, error) {
+	if len(path) == 0 {
+		return Profile{}, errors.New("path is empty")
+	}
+	if sampleMeters <= 0 {
+		return Profile{}, errors.New("sample distance must be positive")
+	}
+
+	points := []*Point{path[0]}
+	distances := []float64{0}
+	for i := 1; i < len(path); i++ {
+		length := path[i-1].GreatCircleDistance(path[i]) * metersPerSeaMile
+		steps := int(math.Max(math.Ceil(length/sampleMeters), 1))
+		start := distances[len(distances)-1]
+		for j := 1; j < steps; j++ {
+			fraction := float64(j) / float64(steps)
+			points = append(points, path[i-1].IntermediatePointTo(path[i], fraction))
+			distances = append(distances, start+length*fraction)
+		}
+		points = append(points, path[i])
+		distances = append(distances, start+length)
+	}
+
+	elevations, err := provider.Elevations(ctx, points)
+	if err != nil {
+		return Profile{}, err
+	}
+	if len(elevations) != len(points) {
+		return Profile{}, fmt.Errorf("expected %d elevations, got %d", len(points), len(elevations))
+	}
+
+	profile := Profile{Min: math.Inf(1), Max: math.Inf(-1), Samples: make([]ProfileSample, len(points))}
+	for i, elevation := range elevations {
+		profile.Samples[i] = ProfileSample{Point: points[i], Distance: distances[i], Elevation: elevation}
+		profile.Min = math.Min(profile.Min, elevation)
+		profile.Max = math.Max(profile.Max, elevation)
+		if i > 0 {
+			if delta := elevation - elevations[i-1]; delta > 0 {
+				profile.Gain += delta
+			} else {
+				profile.Loss -= delta
+			}
+		}
+	}
+	return profile, nil
+}
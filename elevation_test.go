@@ -0,0 +1,98 @@
+package geo
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Writes a synthetic tile of 11x11 samples to the passed in directory, rising by 10 m
+// per column from 0 m at the western to 100 m at the eastern edge.
+func writeSlopeTile(t *testing.T, dir, name string) {
+	const size = 11
+	data := make([]byte, size*size*2)
+	for row := 0; row < size; row++ {
+		for col := 0; col < size; col++ {
+			binary.BigEndian.PutUint16(data[2*(row*size+col):], uint16(col*10))
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Ensures that elevations are interpolated between the samples of a tile.
+func TestSRTMProviderElevations(t *testing.T) {
+	dir, err := ioutil.TempDir("", "srtm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeSlopeTile(t, dir, "N47W123.hgt")
+
+	provider := NewSRTMProvider(dir)
+	elevations, err := provider.Elevations(context.Background(), []*Point{
+		NewPoint(47.5, -123), NewPoint(47.5, -122.5), NewPoint(47.1, -122.05), NewPoint(47.9, -122.75),
+	})
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	expected := []float64{0, 50, 95, 25}
+	for i := range expected {
+		if math.Abs(elevations[i]-expected[i]) > 1e-6 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v, got %v", expected, elevations))
+			break
+		}
+	}
+
+	_, err = provider.Elevations(context.Background(), []*Point{NewPoint(-33.9, 18.4)})
+	if missing, ok := err.(*MissingTileError); !ok || missing.Tile != "S34E018.hgt" {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a missing tile S34E018.hgt, got %v", err))
+	}
+}
+
+// Ensures that the profile samples the path and sums up climb and descent.
+func TestPathElevationProfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "srtm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writeSlopeTile(t, dir, "N47W123.hgt")
+
+	// Eastwards up the slope, then back halfway down
+	path := []*Point{NewPoint(47.5, -122.9), NewPoint(47.5, -122.1), NewPoint(47.5, -122.5)}
+	profile, err := PathElevationProfile(context.Background(), NewSRTMProvider(dir), path, 1000)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+
+	if math.Abs(profile.Gain-80) > 0.1 || math.Abs(profile.Loss-40) > 0.1 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a gain of 80 m and a loss of 40 m, got %v and %v", profile.Gain, profile.Loss))
+	}
+	if math.Abs(profile.Min-10) > 0.1 || math.Abs(profile.Max-90) > 0.1 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected elevations between 10 m and 90 m, got %v and %v", profile.Min, profile.Max))
+	}
+
+	length := (path[0].GreatCircleDistance(path[1]) + path[1].GreatCircleDistance(path[2])) * metersPerSeaMile
+	last := profile.Samples[len(profile.Samples)-1]
+	if math.Abs(last.Distance-length) > 1e-6 || last.Point.lng != path[2].lng {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the last sample at the end of the path, got %v", last))
+	}
+	for i := 1; i < len(profile.Samples); i++ {
+		if step := profile.Samples[i].Distance - profile.Samples[i-1].Distance; step > 1000 || step <= 0 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Samples %d and %d are %v m apart", i-1, i, step))
+		}
+	}
+
+	_, err = PathElevationProfile(context.Background(), NewSRTMProvider(dir), []*Point{NewPoint(47.5, -122.5), NewPoint(47.5, -121.5)}, 1000)
+	if missing, ok := err.(*MissingTileError); !ok || missing.Tile != "N47W122.hgt" {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a missing tile N47W122.hgt, got %v", err))
+	}
+}
@@ -0,0 +1,85 @@
+package geo
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
+
+// An Encoder renders a Point to bytes in one serialization format,
+// with the decimal places of the passed in PrecisionPolicy where the format has any.
+type Encoder func(p *Point, precision PrecisionPolicy) ([]byte, error)
+
+// The encoders run by EncodeAll, by name, guarded by encodersMu.
+var (
+	encoders   = map[string]Encoder{}
+	encodersMu sync.RWMutex
+)
+
+func init() {
+	RegisterEncoder("json", func(p *Point, precision PrecisionPolicy) ([]byte, error) {
+		b, err := precision.AppendJSON(nil, p)
+		if err != nil {
+			return nil, err
+		}
+		// Compacted like json.Marshal does with the output of MarshalJSON
+		return json.Marshal(json.RawMessage(b))
+	})
+	RegisterEncoder("binary", func(p *Point, _ PrecisionPolicy) ([]byte, error) { return p.MarshalBinary() })
+	RegisterEncoder("compact", func(p *Point, _ PrecisionPolicy) ([]byte, error) {
+		return MarshalPointsCompact([]*Point{p}, 7)
+	})
+	for i := range formatNames {
+		format := Format(i)
+		RegisterEncoder(format.String(), func(p *Point, precision PrecisionPolicy) ([]byte, error) {
+			return precision.AppendFormat(nil, p, format)
+		})
+	}
+}
+
+// Registers the passed in Encoder under the passed in name, so EncodeAll runs it too.
+// Registered encoders are covered by the golden file tests of the package,
+// which can be regenerated with "go test -update".
+// Panics if an Encoder is already registered under the name.
+// It is safe to register encoders concurrently with each other and with EncodeAll,
+// though they are usually registered from init functions.
+func RegisterEncoder(name string, encode Encoder) {
+	encodersMu.Lock()
+	defer encodersMu.Unlock()
+	if _, ok := encoders[name]; ok {
+		panic("geo: RegisterEncoder called twice for " + name)
+	}
+	encoders[name] = encode
+}
+
+// Returns the names of all registered encoders, sorted.
+func EncoderNames() []string {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+	names := make([]string, 0, len(encoders))
+	for name := range encoders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Renders the passed in Point with every registered Encoder and DefaultPrecision,
+// and returns the results by encoder name.  Encoders which fail for the Point are left out.
+func EncodeAll(p *Point) map[string][]byte {
+	return defaultPrecision.EncodeAll(p)
+}
+
+// Renders the passed in Point with every registered Encoder and the current PrecisionPolicy,
+// and returns the results by encoder name.  Encoders which fail for the Point are left out.
+func (pp PrecisionPolicy) EncodeAll(p *Point) map[string][]byte {
+	encodersMu.RLock()
+	defer encodersMu.RUnlock()
+	encoded := make(map[string][]byte, len(encoders))
+	for name, encode := range encoders {
+		if data, err := encode(p, pp); err == nil {
+			encoded[name] = data
+		}
+	}
+	return encoded
+}
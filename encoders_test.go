@@ -0,0 +1,157 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// The points every registered encoder is checked against, by the name of their golden files.
+var goldenPoints = map[string]*Point{
+	"empire_state": NewPoint(40.7486, -73.9864),
+	"origin":       NewPoint(0, 0),
+	"south_east":   NewPoint(-45.699750, 69.733722),
+	"north_west":   NewPoint(45.699750, -69.733722),
+	"antimeridian": NewPoint(-16.5, 180),
+	"south_pole":   NewPoint(-90, 0),
+}
+
+// Ensures that every registered encoder renders the golden points exactly as recorded
+// in testdata/golden/<encoder>/<point>.golden.  Run "go test -update" to record new output.
+func TestEncodersGolden(t *testing.T) {
+	for name, p := range goldenPoints {
+		encoded := EncodeAll(p)
+		for _, encoder := range EncoderNames() {
+			actual, ok := encoded[encoder]
+			if !ok {
+				t.Errorf("Expected encoder %s to encode %s", encoder, name)
+				continue
+			}
+
+			path := filepath.Join("testdata", "golden", encoder, name+".golden")
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := ioutil.WriteFile(path, actual, 0644); err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+
+			expected, err := ioutil.ReadFile(path)
+			if err != nil {
+				t.Errorf("Unable to read %s, run go test -update to create it: %v", path, err)
+				continue
+			}
+			if !bytes.Equal(actual, expected) {
+				t.Errorf("Expected encoder %s to render %s as %q, but got %q instead", encoder, name, expected, actual)
+			}
+		}
+	}
+}
+
+// Ensures that registering an encoder twice panics, and that new encoders join EncodeAll.
+func TestRegisterEncoder(t *testing.T) {
+	RegisterEncoder("test_lat", func(p *Point, _ PrecisionPolicy) ([]byte, error) { return []byte("lat"), nil })
+	defer delete(encoders, "test_lat")
+
+	if string(EncodeAll(NewPoint(1, 2))["test_lat"]) != "lat" {
+		t.Error("Expected a registered encoder to be run by EncodeAll")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected registering an encoder twice to panic")
+		}
+	}()
+	RegisterEncoder("json", nil)
+}
+
+// Ensures that encoders can be registered while others are encoding.
+func TestRegisterEncoderConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("test_concurrent_%d", i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterEncoder(name, func(p *Point, _ PrecisionPolicy) ([]byte, error) { return []byte(name), nil })
+		}()
+		go func() {
+			defer wg.Done()
+			EncodeAll(NewPoint(1, 2))
+			EncoderNames()
+		}()
+	}
+	wg.Wait()
+
+	encoded := EncodeAll(NewPoint(1, 2))
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("test_concurrent_%d", i)
+		if string(encoded[name]) != name {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected the encoder %s to be registered, but got %q", name, encoded[name]))
+		}
+		delete(encoders, name)
+	}
+}
+
+// Ensures that a uniform PrecisionPolicy renders the coordinates identically through every encoder in decimal degrees.
+func TestEncodeAllPrecision(t *testing.T) {
+	precision := UniformPrecision(5)
+
+	p := NewPoint(40.74861234, -73.98644321)
+	expected := []string{"40.74861", "-73.98644"}
+	numbers := regexp.MustCompile(`-?\d+\.\d+`)
+	encoded := precision.EncodeAll(p)
+	for _, name := range []string{"json", "decimal_degrees"} {
+		if found := numbers.FindAllString(string(encoded[name]), -1); fmt.Sprint(found) != fmt.Sprint(expected) {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected the %s encoder to render %v, but got %s", name, expected, encoded[name]))
+		}
+	}
+
+	var csv bytes.Buffer
+	WritePointsCSV(&csv, []*Point{p}, precision.FormatDecimals)
+	converted, err := ioutil.ReadAll(NewFormatConverter(strings.NewReader("40.74861234,-73.98644321\n"), DecimalDegrees, DecimalDegrees,
+		ConvertOptions{Precision: &precision}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rendered := range []string{csv.String(), string(converted)} {
+		if found := numbers.FindAllString(rendered, -1); fmt.Sprint(found) != fmt.Sprint(expected) {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v, but got %s", expected, rendered))
+		}
+	}
+
+	// JSON stays valid and decodes to the rounded coordinates
+	var decoded Point
+	if err := json.Unmarshal(encoded["json"], &decoded); err != nil || decoded.lat != 40.74861 || decoded.lng != -73.98644 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected [40.74861, -73.98644], but got %v (%v)", decoded, err))
+	}
+
+	// Shortest renders the coordinates exactly everywhere
+	encoded = UniformPrecision(ShortestPrecision).EncodeAll(p)
+	for _, name := range []string{"json", "decimal_degrees"} {
+		if found := numbers.FindAllString(string(encoded[name]), -1); fmt.Sprint(found) != "[40.74861234 -73.98644321]" {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected the %s encoder to render the exact coordinates, but got %s", name, encoded[name]))
+		}
+	}
+
+	// The methods of Point keep using the default
+	if s, _ := p.Format(DecimalDegrees); s != "40.748612,-73.986443" {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the default precision, but got %s", s))
+	}
+	if b, _ := DefaultPrecision().AppendJSON([]byte("x"), p); string(b) != `x{"lat":40.74861234, "lng":-73.98644321}` {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the default JSON, but got %s", b))
+	}
+}
@@ -0,0 +1,105 @@
+package geo
+
+import (
+	"math"
+)
+
+// A GeofenceSet holds a number of circular and polygonal geofences
+// and finds the ones containing a point, skipping all geofences
+// whose bounding box does not contain the point.
+type GeofenceSet struct {
+	fences []geofence
+}
+
+// A single geofence of a GeofenceSet, either a circle or a Polygon, and its bounding box.
+// The longitudes of the bounding box may exceed ±180 for geofences crossing the antimeridian.
+type geofence struct {
+	center  *Point
+	radius  float64
+	polygon *Polygon
+
+	minLat, minLng, maxLat, maxLng float64
+}
+
+// Creates and returns a pointer to a new, empty GeofenceSet.
+func NewGeofenceSet() *GeofenceSet {
+	return &GeofenceSet{}
+}
+
+// Adds a circular geofence with the passed in center and radius (in sea miles)
+// and returns its index.
+func (gs *GeofenceSet) AddCircle(center *Point, radius float64) int {
+	minLat, minLng, maxLat, maxLng := circleBounds(center, radius)
+	gs.fences = append(gs.fences, geofence{
+		center: center,
+		radius: radius,
+		minLat: minLat,
+		minLng: minLng,
+		maxLat: maxLat,
+		maxLng: maxLng,
+	})
+	return len(gs.fences) - 1
+}
+
+// Returns the bounding box of the circle with the passed in center and radius (in sea miles).
+// The longitudes may exceed ±180 for circles crossing the antimeridian, and span all longitudes
+// for circles containing a pole.
+func circleBounds(center *Point, radius float64) (minLat, minLng, maxLat, maxLng float64) {
+	dist := radius / EARTHRADIUS * 180.0 / math.Pi
+	minLat, maxLat = center.lat-dist, center.lat+dist
+	minLng, maxLng = -180, 180
+
+	// Unless the circle contains a pole, its widest extent in longitude
+	// is found where a meridian touches it.
+	if minLat > -90 && maxLat < 90 {
+		dLng := math.Asin(math.Sin(radius/EARTHRADIUS)/math.Cos(center.lat*math.Pi/180.0)) * 180.0 / math.Pi
+		minLng, maxLng = center.lng-dLng, center.lng+dLng
+	}
+	return minLat, minLng, maxLat, maxLng
+}
+
+// Adds a polygonal geofence and returns its index.
+func (gs *GeofenceSet) AddPolygon(polygon *Polygon) int {
+	minLat, minLng, maxLat, maxLng := polygon.bounds()
+	gs.fences = append(gs.fences, geofence{
+		polygon: polygon,
+		minLat:  minLat,
+		minLng:  minLng,
+		maxLat:  maxLat,
+		maxLng:  maxLng,
+	})
+	return len(gs.fences) - 1
+}
+
+// Returns the number of geofences in the set.
+func (gs *GeofenceSet) Len() int {
+	return len(gs.fences)
+}
+
+// Returns the indices of all geofences containing the passed in point, in ascending order.
+func (gs *GeofenceSet) Containing(p *Point) []int {
+	indices := []int{}
+	for i, fence := range gs.fences {
+		if fence.contains(p) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// Returns whether or not the geofence contains the passed in point.
+func (f *geofence) contains(p *Point) bool {
+	if p.lat < f.minLat || p.lat > f.maxLat {
+		return false
+	}
+	if !(p.lng >= f.minLng && p.lng <= f.maxLng) &&
+		!(p.lng+360 >= f.minLng && p.lng+360 <= f.maxLng) &&
+		!(p.lng-360 >= f.minLng && p.lng-360 <= f.maxLng) {
+		return false
+	}
+
+	if f.polygon != nil {
+		return f.polygon.Contains(p)
+	}
+	return f.center.GreatCircleDistance(p) <= f.radius
+}
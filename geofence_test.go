@@ -0,0 +1,52 @@
+package geo
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Ensures that a point falling into two overlapping geofences is reported for both.
+func TestGeofenceSetContaining(t *testing.T) {
+	gs := NewGeofenceSet()
+	downtown := gs.AddCircle(NewPoint(47.6062, -122.3321), 2)
+	square := gs.AddPolygon(NewPolygon([]*Point{
+		NewPoint(47.5, -122.4), NewPoint(47.5, -122.3), NewPoint(47.7, -122.3), NewPoint(47.7, -122.4),
+	}))
+	tacoma := gs.AddCircle(NewPoint(47.2529, -122.4443), 5)
+
+	if gs.Len() != 3 {
+		t.Errorf("Expected 3 geofences, but got %d instead", gs.Len())
+	}
+
+	var containingtests = []struct {
+		in  *Point
+		out []int
+	}{
+		{NewPoint(47.61, -122.33), []int{downtown, square}},
+		{NewPoint(47.61, -122.39), []int{square}},
+		{NewPoint(47.61, -122.29), []int{downtown}},
+		{NewPoint(47.25, -122.44), []int{tacoma}},
+		{NewPoint(40.7486, -73.9864), []int{}},
+	}
+
+	for _, tt := range containingtests {
+		if indices := gs.Containing(tt.in); !reflect.DeepEqual(indices, tt.out) {
+			t.Errorf("Expected %v to be contained in %v, but got %v instead", tt.in, tt.out, indices)
+		}
+	}
+}
+
+// Ensures that circles crossing the antimeridian or containing a pole are not filtered out wrongly.
+func TestGeofenceSetEdgeCases(t *testing.T) {
+	gs := NewGeofenceSet()
+	gs.AddCircle(NewPoint(0, 179.9), 30)
+	gs.AddCircle(NewPoint(89.9, 0), 30)
+
+	if indices := gs.Containing(NewPoint(0, -179.9)); !reflect.DeepEqual(indices, []int{0}) {
+		t.Errorf("Expected a point across the antimeridian to be contained, but got %v instead", indices)
+	}
+
+	if indices := gs.Containing(NewPoint(89.9, 180)); !reflect.DeepEqual(indices, []int{1}) {
+		t.Errorf("Expected a point across the pole to be contained, but got %v instead", indices)
+	}
+}
@@ -0,0 +1,123 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// The alphabet of the base 32 encoding used by geohashes.
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// Returns the south-west and north-east corners of the cell of the passed in geohash.
+func geohashBounds(hash string) (sw, ne *Point, err error) {
+	minLat, maxLat, minLng, maxLng := -90.0, 90.0, -180.0, 180.0
+	even := true
+	for i := 0; i < len(hash); i++ {
+		bits := strings.IndexByte(geohashAlphabet, hash[i]|0x20)
+		if bits < 0 {
+			return nil, nil, fmt.Errorf("Invalid character %q in geohash %q", hash[i], hash)
+		}
+		for mask := 16; mask > 0; mask >>= 1 {
+			if even {
+				mid := (minLng + maxLng) / 2
+				if bits&mask != 0 {
+					minLng = mid
+				} else {
+					maxLng = mid
+				}
+			} else {
+				mid := (minLat + maxLat) / 2
+				if bits&mask != 0 {
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return NewPoint(minLat, minLng), NewPoint(maxLat, maxLng), nil
+}
+
+// Returns the geohash of the passed in precision of the cell containing the passed in coordinates.
+func geohashEncode(lat, lng float64, precision int) string {
+	minLat, maxLat, minLng, maxLng := -90.0, 90.0, -180.0, 180.0
+	hash := make([]byte, precision)
+	even := true
+	for i := range hash {
+		bits := 0
+		for n := 0; n < 5; n++ {
+			bits <<= 1
+			if even {
+				if mid := (minLng + maxLng) / 2; lng >= mid {
+					bits |= 1
+					minLng = mid
+				} else {
+					maxLng = mid
+				}
+			} else {
+				if mid := (minLat + maxLat) / 2; lat >= mid {
+					bits |= 1
+					minLat = mid
+				} else {
+					maxLat = mid
+				}
+			}
+			even = !even
+		}
+		hash[i] = geohashAlphabet[bits]
+	}
+	return string(hash)
+}
+
+// Returns the height and width (in degrees) of the cells of geohashes of the passed in precision.
+func geohashCellSize(precision int) (latDeg, lngDeg float64) {
+	bits := 5 * precision
+	return 180 / math.Pow(2, float64(bits/2)), 360 / math.Pow(2, float64(bits-bits/2))
+}
+
+// Returns the center of the cell of the geohash of the passed in precision containing the passed in point,
+// so that all points within one cell snap to the same center.
+func SnapToGeohashCenter(p *Point, precision int) *Point {
+	sw, ne, _ := geohashBounds(geohashEncode(p.lat, p.lng, precision))
+	return NewPoint((sw.lat+ne.lat)/2, (sw.lng+ne.lng)/2)
+}
+
+// Returns the distances (in sea miles) from the passed in point to the cells of every prefix of the passed in geohash,
+// by the length of the prefix, from 1 to the length of the geohash.  The distance to a cell containing the point is 0,
+// so the distances never decrease as the prefixes grow longer.
+func GeohashDistances(p *Point, hash string) (map[int]float64, error) {
+	distances := make(map[int]float64, len(hash))
+	for precision := 1; precision <= len(hash); precision++ {
+		sw, ne, err := geohashBounds(hash[:precision])
+		if err != nil {
+			return nil, err
+		}
+		distances[precision] = distanceToBox(p, sw, ne)
+	}
+	return distances, nil
+}
+
+// Returns the shortest distance (in sea miles) from the passed in point to the box spanned by the passed in
+// south-west and north-east corners, where the box does not cross the antimeridian, or 0 if the box contains the point.
+func distanceToBox(p, sw, ne *Point) float64 {
+	if p.lng >= sw.lng && p.lng <= ne.lng {
+		switch {
+		case p.lat < sw.lat:
+			return latitudeDistance(sw.lat - p.lat)
+		case p.lat > ne.lat:
+			return latitudeDistance(p.lat - ne.lat)
+		}
+		return 0
+	}
+
+	// Outside of the box's longitudes, the nearest point of its parallels is one of their ends,
+	// so the nearest point lies on one of its meridians.
+	west := distanceToSegment(sw, NewPoint(ne.lat, sw.lng), p)
+	east := distanceToSegment(NewPoint(sw.lat, ne.lng), ne, p)
+	if east < west {
+		return east
+	}
+	return west
+}
@@ -0,0 +1,92 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Ensures that geohashes are decoded to the bounds of their cells.
+func TestGeohashBounds(t *testing.T) {
+	sw, ne, err := geohashBounds("u4pruydqqvj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(sw.lat <= 57.64911 && ne.lat >= 57.64911 && sw.lng <= 10.40744 && ne.lng >= 10.40744) || ne.lat-sw.lat > 1e-5 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a cell around [57.64911, 10.40744], but got %v to %v", sw, ne))
+	}
+
+	if _, _, err := geohashBounds("u4pa"); err == nil {
+		t.Error("Expected an error decoding a geohash containing an a")
+	}
+}
+
+// Ensures that points are encoded to the geohashes of the cells containing them.
+func TestGeohashEncode(t *testing.T) {
+	if hash := geohashEncode(57.64911, 10.40744, 11); hash != "u4pruydqqvj" {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected u4pruydqqvj, but got %s", hash))
+	}
+
+	latDeg, lngDeg := geohashCellSize(5)
+	sw, ne, _ := geohashBounds(geohashEncode(-33.8, 151.2, 5))
+	if math.Abs(ne.lat-sw.lat-latDeg) > 1e-12 || math.Abs(ne.lng-sw.lng-lngDeg) > 1e-12 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected cells of %v by %v degrees, but got %v to %v", latDeg, lngDeg, sw, ne))
+	}
+}
+
+// Ensures that the distances to the cells of a geohash's prefixes never increase as the prefixes get shorter.
+func TestGeohashDistances(t *testing.T) {
+	distances, err := GeohashDistances(NewPoint(40, 10), "u4pruydqqvj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(distances) != 11 {
+		t.Fatalf("Expected a distance for each of the 11 precisions, but got %v instead", distances)
+	}
+	for precision := 1; precision < 11; precision++ {
+		if distances[precision] > distances[precision+1] {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected the distance at precision %d to be at most the one at %d, got %v", precision, precision+1, distances))
+		}
+	}
+
+	// The cell "u" spans latitudes 45 to 90 and longitudes 0 to 45
+	if math.Abs(distances[1]-NewPoint(40, 10).GreatCircleDistance(NewPoint(45, 10))) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the distance to the cell u to be 5 degrees of latitude, got %v", distances[1]))
+	}
+
+	// West of the cell "u4", which spans longitudes 0 to 11.25
+	west, _ := GeohashDistances(NewPoint(60, -1), "u4")
+	if expected := distanceToSegment(NewPoint(56.25, 0), NewPoint(61.875, 0), NewPoint(60, -1)); math.Abs(west[2]-expected) > 1e-9 || west[2] <= 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a distance of %v to the cell u4, got %v", expected, west[2]))
+	}
+
+	inside, _ := GeohashDistances(NewPoint(57.64911, 10.40744), "u4pruydqqvj")
+	for precision, dist := range inside {
+		if dist != 0 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected a distance of 0 at precision %d, got %v", precision, dist))
+		}
+	}
+}
+
+// Ensures that nearby points within one geohash cell snap to the same center.
+func TestSnapToGeohashCenter(t *testing.T) {
+	// The cell u4pru spans latitudes 57.6123 to 57.6562 and longitudes 10.3711 to 10.4150
+	a := SnapToGeohashCenter(NewPoint(57.64911, 10.40744), 5)
+	b := SnapToGeohashCenter(NewPoint(57.62, 10.38), 5)
+	if a.lat != b.lat || a.lng != b.lng {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected both points to snap to the same center, but got %v and %v", a, b))
+	}
+	if hash := geohashEncode(a.lat, a.lng, 5); hash != "u4pru" {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the center to lie in u4pru, but got %s", hash))
+	}
+
+	latDeg, lngDeg := geohashCellSize(5)
+	sw, _, _ := geohashBounds("u4pru")
+	if math.Abs(a.lat-sw.lat-latDeg/2) > 1e-12 || math.Abs(a.lng-sw.lng-lngDeg/2) > 1e-12 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the center of the cell, but got %v", a))
+	}
+
+	if c := SnapToGeohashCenter(NewPoint(57.66, 10.38), 5); c.lat == a.lat && c.lng == a.lng {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a point of the next cell north to snap elsewhere, but got %v", c))
+	}
+}
@@ -0,0 +1,282 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// The severity of a ValidationIssue.
+type Severity int
+
+const (
+	// The GeoJSON is valid, but violates a recommendation of RFC 7946
+	SeverityWarning Severity = iota
+	// The GeoJSON is invalid
+	SeverityError
+)
+
+// Returns the name of the severity, e.g. "error".
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "Severity(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// A ValidationIssue is a problem found by ValidateGeoJSON.
+type ValidationIssue struct {
+	// JSON Pointer (RFC 6901) to the offending member, e.g. "/features/0/geometry/coordinates/0"
+	Pointer  string
+	Severity Severity
+	Message  string
+}
+
+// Returns a human readable description of the issue.
+func (i ValidationIssue) String() string {
+	return fmt.Sprintf("%s at %q: %s", i.Severity, i.Pointer, i.Message)
+}
+
+// Validates the passed in GeoJSON document against RFC 7946 and returns all issues found.
+// Errors are reported for malformed JSON, unknown types, positions out of range,
+// rings with too few positions or not closed, and bbox members which do not bound their geometry.
+// Warnings are reported for rings with the wrong winding order (exterior rings should be
+// counter clockwise, holes clockwise) and for lines crossing the antimeridian, which should be split.
+func ValidateGeoJSON(data []byte) []ValidationIssue {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return []ValidationIssue{{Pointer: "", Severity: SeverityError, Message: "invalid JSON: " + err.Error()}}
+	}
+
+	v := &geoJSONValidator{issues: []ValidationIssue{}}
+	v.object(doc, "")
+	return v.issues
+}
+
+// Collects the issues found while walking a GeoJSON document.
+type geoJSONValidator struct {
+	issues []ValidationIssue
+}
+
+func (v *geoJSONValidator) report(pointer string, severity Severity, format string, args ...interface{}) {
+	v.issues = append(v.issues, ValidationIssue{Pointer: pointer, Severity: severity, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validates a GeoJSON object and returns all of its positions.
+func (v *geoJSONValidator) object(value interface{}, pointer string) []*Point {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		v.report(pointer, SeverityError, "expected a GeoJSON object")
+		return nil
+	}
+
+	var points []*Point
+	switch t, _ := obj["type"].(string); t {
+	case "FeatureCollection":
+		features, _ := obj["features"].([]interface{})
+		if features == nil {
+			v.report(pointer+"/features", SeverityError, "expected an array of features")
+		}
+		for i, feature := range features {
+			points = append(points, v.object(feature, pointer+"/features/"+strconv.Itoa(i))...)
+		}
+	case "Feature":
+		if geometry := obj["geometry"]; geometry != nil {
+			points = v.object(geometry, pointer+"/geometry")
+		}
+	case "GeometryCollection":
+		geometries, _ := obj["geometries"].([]interface{})
+		if geometries == nil {
+			v.report(pointer+"/geometries", SeverityError, "expected an array of geometries")
+		}
+		for i, geometry := range geometries {
+			points = append(points, v.object(geometry, pointer+"/geometries/"+strconv.Itoa(i))...)
+		}
+	case "Point", "MultiPoint", "LineString", "MultiLineString", "Polygon", "MultiPolygon":
+		points = v.coordinates(t, obj["coordinates"], pointer+"/coordinates")
+	default:
+		v.report(pointer+"/type", SeverityError, "unknown type %q", t)
+	}
+
+	if bbox, ok := obj["bbox"]; ok {
+		v.bbox(bbox, points, pointer+"/bbox")
+	}
+
+	return points
+}
+
+// Validates the coordinates of a geometry of the passed in type and returns its positions.
+func (v *geoJSONValidator) coordinates(t string, value interface{}, pointer string) []*Point {
+	switch t {
+	case "Point":
+		if p := v.position(value, pointer); p != nil {
+			return []*Point{p}
+		}
+		return nil
+	case "MultiPoint":
+		return v.positions(value, pointer)
+	case "LineString":
+		return v.line(value, pointer)
+	case "Polygon":
+		return v.polygon(value, pointer)
+	}
+
+	// The multi geometries are arrays of their single counterparts
+	parts, ok := value.([]interface{})
+	if !ok {
+		v.report(pointer, SeverityError, "expected an array")
+		return nil
+	}
+	var points []*Point
+	for i, part := range parts {
+		if t == "MultiLineString" {
+			points = append(points, v.line(part, pointer+"/"+strconv.Itoa(i))...)
+		} else {
+			points = append(points, v.polygon(part, pointer+"/"+strconv.Itoa(i))...)
+		}
+	}
+	return points
+}
+
+// Validates a single position and returns it as a Point, or nil if it is malformed.
+func (v *geoJSONValidator) position(value interface{}, pointer string) *Point {
+	coords, _ := value.([]interface{})
+	if len(coords) < 2 {
+		v.report(pointer, SeverityError, "expected a position of at least two numbers")
+		return nil
+	}
+	lng, ok1 := coords[0].(float64)
+	lat, ok2 := coords[1].(float64)
+	if !ok1 || !ok2 {
+		v.report(pointer, SeverityError, "expected a position of at least two numbers")
+		return nil
+	}
+
+	if lng < -180 || lng > 180 {
+		v.report(pointer+"/0", SeverityError, "longitude %v out of range [-180, 180]", lng)
+	}
+	if lat < -90 || lat > 90 {
+		v.report(pointer+"/1", SeverityError, "latitude %v out of range [-90, 90]", lat)
+	}
+
+	return NewPoint(lat, lng)
+}
+
+// Validates an array of positions and returns the well formed ones.
+func (v *geoJSONValidator) positions(value interface{}, pointer string) []*Point {
+	values, ok := value.([]interface{})
+	if !ok {
+		v.report(pointer, SeverityError, "expected an array of positions")
+		return nil
+	}
+	points := []*Point{}
+	for i, position := range values {
+		if p := v.position(position, pointer+"/"+strconv.Itoa(i)); p != nil {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// Validates the positions of a line and returns them.
+func (v *geoJSONValidator) line(value interface{}, pointer string) []*Point {
+	points := v.positions(value, pointer)
+	if points != nil && len(points) < 2 {
+		v.report(pointer, SeverityError, "a line needs at least two positions, got %d", len(points))
+	}
+	v.antimeridian(points, pointer)
+	return points
+}
+
+// Validates the rings of a polygon and returns their positions.
+func (v *geoJSONValidator) polygon(value interface{}, pointer string) []*Point {
+	rings, ok := value.([]interface{})
+	if !ok {
+		v.report(pointer, SeverityError, "expected an array of linear rings")
+		return nil
+	}
+
+	var points []*Point
+	for i, ring := range rings {
+		ringPointer := pointer + "/" + strconv.Itoa(i)
+		positions := v.positions(ring, ringPointer)
+		points = append(points, positions...)
+		if positions == nil {
+			continue
+		}
+
+		if len(positions) < 4 {
+			v.report(ringPointer, SeverityError, "a linear ring needs at least four positions, got %d", len(positions))
+			continue
+		}
+		first, last := positions[0], positions[len(positions)-1]
+		if first.lat != last.lat || first.lng != last.lng {
+			v.report(ringPointer, SeverityError, "linear ring is not closed, the first and last positions differ")
+			continue
+		}
+
+		v.antimeridian(positions, ringPointer)
+
+		area := NewPolygonNoCopy(positions[:len(positions)-1]).signedArea()
+		if i == 0 && area < 0 {
+			v.report(ringPointer, SeverityWarning, "exterior ring should be counter clockwise")
+		} else if i > 0 && area > 0 {
+			v.report(ringPointer, SeverityWarning, "interior ring should be clockwise")
+		}
+	}
+	return points
+}
+
+// Reports consecutive positions which jump across the antimeridian.
+func (v *geoJSONValidator) antimeridian(points []*Point, pointer string) {
+	for i := 1; i < len(points); i++ {
+		if math.Abs(points[i].lng-points[i-1].lng) > 180 {
+			v.report(pointer+"/"+strconv.Itoa(i), SeverityWarning, "crosses the antimeridian and should be split")
+		}
+	}
+}
+
+// Validates a bbox member against the positions of the geometry it belongs to.
+func (v *geoJSONValidator) bbox(value interface{}, points []*Point, pointer string) {
+	values, _ := value.([]interface{})
+	bbox := []float64{}
+	for _, value := range values {
+		if n, ok := value.(float64); ok {
+			bbox = append(bbox, n)
+		}
+	}
+	if len(bbox) != len(values) || (len(bbox) != 4 && len(bbox) != 6) {
+		v.report(pointer, SeverityError, "expected a bbox of 4 or 6 numbers")
+		return
+	}
+
+	// The altitudes are in the middle of 6 number bboxes
+	half := len(bbox) / 2
+	west, south, east, north := bbox[0], bbox[1], bbox[half], bbox[half+1]
+	if len(points) == 0 {
+		return
+	}
+
+	minLat, minLng, maxLat, maxLng := NewPolygonNoCopy(points).bounds()
+	outside := minLat < south || maxLat > north
+	if west <= east {
+		outside = outside || minLng < west || maxLng > east
+	} else {
+		// A bbox crossing the antimeridian
+		for _, p := range points {
+			if p.lng < west && p.lng > east {
+				outside = true
+			}
+		}
+	}
+
+	if outside {
+		v.report(pointer, SeverityError, "bbox [%v, %v, %v, %v] does not contain the geometry spanning [%v, %v, %v, %v]",
+			west, south, east, north, minLng, minLat, maxLng, maxLat)
+	}
+}
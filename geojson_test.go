@@ -0,0 +1,77 @@
+package geo
+
+import (
+	"testing"
+)
+
+// Asserts that exactly one issue was found at the passed in pointer with the passed in severity.
+func assertIssue(t *testing.T, issues []ValidationIssue, pointer string, severity Severity) {
+	found := 0
+	for _, issue := range issues {
+		if issue.Pointer == pointer && issue.Severity == severity {
+			found++
+		}
+	}
+	if found != 1 {
+		t.Errorf("Expected one %s at %q, but got %v instead", severity, pointer, issues)
+	}
+}
+
+// Ensures that valid GeoJSON passes without any issues.
+func TestValidateGeoJSONValid(t *testing.T) {
+	data := []byte(`{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "properties": {}, "bbox": [0, 0, 1, 1],
+		 "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1], [0, 0]]]}},
+		{"type": "Feature", "properties": {}, "geometry": {"type": "Point", "coordinates": [-122.3, 47.6]}},
+		{"type": "Feature", "properties": {}, "geometry": {"type": "LineString", "coordinates": [[170, 0], [180, 1]]}},
+		{"type": "Feature", "properties": {}, "bbox": [170, 0, -170, 1],
+		 "geometry": {"type": "MultiPoint", "coordinates": [[175, 0], [-175, 1]]}}
+	]}`)
+
+	if issues := ValidateGeoJSON(data); len(issues) != 0 {
+		t.Errorf("Expected no issues, but got %v instead", issues)
+	}
+}
+
+// Ensures that each deliberately broken part of a document is reported.
+func TestValidateGeoJSONBroken(t *testing.T) {
+	data := []byte(`{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1]]]}},
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [10, 95]}},
+		{"type": "Feature", "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [0, 1], [1, 1], [1, 0], [0, 0]]]}},
+		{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[179, 0], [-179, 1]]}},
+		{"type": "Feature", "bbox": [0, 0, 1, 1], "geometry": {"type": "Point", "coordinates": [2, 2]}},
+		{"type": "Feature", "geometry": {"type": "Circle", "coordinates": [0, 0]}}
+	]}`)
+
+	issues := ValidateGeoJSON(data)
+	if len(issues) != 6 {
+		t.Errorf("Expected 6 issues, but got %d instead: %v", len(issues), issues)
+	}
+
+	assertIssue(t, issues, "/features/0/geometry/coordinates/0", SeverityError)
+	assertIssue(t, issues, "/features/1/geometry/coordinates/1", SeverityError)
+	assertIssue(t, issues, "/features/2/geometry/coordinates/0", SeverityWarning)
+	assertIssue(t, issues, "/features/3/geometry/coordinates/1", SeverityWarning)
+	assertIssue(t, issues, "/features/4/bbox", SeverityError)
+	assertIssue(t, issues, "/features/5/geometry/type", SeverityError)
+}
+
+// Ensures that holes are expected to be wound clockwise and that malformed JSON is reported.
+func TestValidateGeoJSONHolesAndSyntax(t *testing.T) {
+	data := []byte(`{"type": "MultiPolygon", "coordinates": [[
+		[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]],
+		[[2, 2], [4, 2], [4, 4], [2, 4], [2, 2]]
+	]]}`)
+
+	issues := ValidateGeoJSON(data)
+	if len(issues) != 1 {
+		t.Errorf("Expected 1 issue, but got %v instead", issues)
+	}
+	assertIssue(t, issues, "/coordinates/0/1", SeverityWarning)
+
+	issues = ValidateGeoJSON([]byte(`{"type": `))
+	if len(issues) != 1 || issues[0].Severity != SeverityError || issues[0].Pointer != "" {
+		t.Errorf("Expected a single error for malformed JSON, but got %v instead", issues)
+	}
+}
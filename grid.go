@@ -0,0 +1,312 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// A PointGrid is a regular grid of points covering the box spanned by
+// a south-west and a north-east corner, at fixed latitude and longitude steps.
+// Points are ordered row by row from the south-west corner, west to east
+// within each row.  If the north-east corner lies west of the south-west corner,
+// the box is considered to cross the antimeridian.
+type PointGrid struct {
+	sw      *Point
+	latStep float64
+	lngStep float64
+	rows    int
+	cols    int
+
+	next    int
+	current Point
+}
+
+// Creates and returns a pointer to a new PointGrid covering the box from the passed in
+// south-west to the passed in north-east corner, at the passed in steps (in degrees).
+// The corners themselves are part of the grid when the steps divide the box evenly.
+func NewPointGrid(sw, ne *Point, latStep, lngStep float64) *PointGrid {
+	lngSpan := ne.lng - sw.lng
+	if lngSpan < 0 {
+		lngSpan += 360
+	}
+
+	return &PointGrid{
+		sw:      sw,
+		latStep: latStep,
+		lngStep: lngStep,
+		rows:    gridSteps(ne.lat-sw.lat, latStep),
+		cols:    gridSteps(lngSpan, lngStep),
+	}
+}
+
+// Returns the number of grid points along a span, allowing for rounding errors.
+func gridSteps(span float64, step float64) int {
+	if span < 0 || step <= 0 {
+		return 0
+	}
+	return int(math.Floor(span/step+1e-9)) + 1
+}
+
+// Returns the number of points in the grid without generating them.
+func (g *PointGrid) Count() int {
+	return g.rows * g.cols
+}
+
+// Returns all of the points in the grid.
+func (g *PointGrid) Points() []*Point {
+	points := make([]*Point, 0, g.Count())
+	for i := 0; i < g.Count(); i++ {
+		points = append(points, g.point(i))
+	}
+	return points
+}
+
+// Returns the next point of the grid and true, or nil and false once all points have been returned.
+// To avoid allocating, the same Point is reused by every call, so callers
+// need to copy it if they want to keep it around.
+func (g *PointGrid) Next() (*Point, bool) {
+	if g.next >= g.Count() {
+		return nil, false
+	}
+	g.current = *g.point(g.next)
+	g.next++
+	return &g.current, true
+}
+
+// Restarts the iteration performed by Next at the south-west corner.
+func (g *PointGrid) Reset() {
+	g.next = 0
+}
+
+// Returns the points of the grid for which the passed in predicate returns true.
+// The grid is walked lazily, so only the matching points are ever allocated.
+// The predicate must not keep the Point it is passed.
+func (g *PointGrid) Filter(predicate func(*Point) bool) []*Point {
+	points := []*Point{}
+	var candidate Point
+	for i := 0; i < g.Count(); i++ {
+		candidate = g.pointValue(i)
+		if predicate(&candidate) {
+			p := candidate
+			points = append(points, &p)
+		}
+	}
+	return points
+}
+
+// Returns a pointer to a new Point at the passed in index of the grid.
+func (g *PointGrid) point(i int) *Point {
+	p := g.pointValue(i)
+	return &p
+}
+
+// Returns the Point at the passed in index of the grid.
+func (g *PointGrid) pointValue(i int) Point {
+	lat := g.sw.lat + float64(i/g.cols)*g.latStep
+	lng := g.sw.lng + float64(i%g.cols)*g.lngStep
+	if lng >= 180 {
+		lng -= 360
+	}
+	return Point{lat: lat, lng: lng}
+}
+
+// Counts the passed in points falling into each cell of a grid of the passed in number of
+// rows and columns, evenly dividing the box from the passed in south-west to the passed in
+// north-east corner.  The returned counts are indexed by row and then column, with row 0 at
+// the southern and column 0 at the western edge.  Points on the northern or eastern edge are
+// counted in the last row or column, points outside of the box are ignored.
+// If the north-east corner lies west of the south-west corner, the box is considered to cross the antimeridian.
+func HeatmapGrid(points []*Point, sw, ne *Point, rows, cols int) [][]int {
+	if rows <= 0 || cols <= 0 {
+		return [][]int{}
+	}
+
+	counts := make([][]int, rows)
+	for i := range counts {
+		counts[i] = make([]int, cols)
+	}
+
+	latSpan := ne.lat - sw.lat
+	lngSpan := ne.lng - sw.lng
+	if lngSpan < 0 {
+		lngSpan += 360
+	}
+	if latSpan <= 0 || lngSpan <= 0 {
+		return counts
+	}
+
+	for _, p := range points {
+		dLat := p.lat - sw.lat
+		dLng := p.lng - sw.lng
+		if dLng < 0 {
+			dLng += 360
+		}
+		if dLat < 0 || dLat > latSpan || dLng > lngSpan {
+			continue
+		}
+
+		row := int(math.Min(math.Floor(dLat/latSpan*float64(rows)), float64(rows-1)))
+		col := int(math.Min(math.Floor(dLng/lngSpan*float64(cols)), float64(cols-1)))
+		counts[row][col]++
+	}
+
+	return counts
+}
+
+// Returns a grid of the passed in number of rows and columns, evenly dividing the box from the passed in
+// south-west to the passed in north-east corner, holding the great circle distance (in sea miles)
+// from the center of each cell to the nearest of the passed in origins.  The distances are indexed
+// by row and then column, with row 0 at the southern and column 0 at the western edge.
+// Without any origins every cell is +Inf.
+// If the north-east corner lies west of the south-west corner, the box is considered to cross the antimeridian.
+func DistanceField(origins []*Point, sw, ne *Point, rows, cols int) [][]float64 {
+	if rows <= 0 || cols <= 0 {
+		return [][]float64{}
+	}
+
+	lngSpan := ne.lng - sw.lng
+	if lngSpan < 0 {
+		lngSpan += 360
+	}
+	latStep := (ne.lat - sw.lat) / float64(rows)
+	lngStep := lngSpan / float64(cols)
+
+	index := newLatitudeIndex(origins)
+	field := make([][]float64, rows)
+	for row := range field {
+		field[row] = make([]float64, cols)
+		for col := range field[row] {
+			lng := sw.lng + (float64(col)+0.5)*lngStep
+			if lng >= 180 {
+				lng -= 360
+			}
+			field[row][col] = index.nearest(&Point{lat: sw.lat + (float64(row)+0.5)*latStep, lng: lng})
+		}
+	}
+	return field
+}
+
+// Returns a raster of the box spanned by the passed in south-west and north-east corners, divided into
+// square cells of the passed in size (in degrees), holding the index of the site nearest to the center
+// of each cell by great circle distance.  Ties are broken by the lowest index.  The labels are indexed
+// by row and then column, with row 0 at the southern and column 0 at the western edge.
+// Without any sites every cell is -1.
+// If the north-east corner lies west of the south-west corner, the box is considered to cross the antimeridian.
+func NearestSiteLabels(sites []*Point, sw, ne *Point, cellDeg float64) [][]int {
+	if cellDeg <= 0 {
+		return [][]int{}
+	}
+
+	lngSpan := ne.lng - sw.lng
+	if lngSpan < 0 {
+		lngSpan += 360
+	}
+	rows := int(math.Ceil((ne.lat-sw.lat)/cellDeg - 1e-9))
+	cols := int(math.Ceil(lngSpan/cellDeg - 1e-9))
+
+	index := newLatitudeIndex(sites)
+	labels := make([][]int, 0, rows)
+	for row := 0; row < rows; row++ {
+		lat := math.Min(sw.lat+(float64(row)+0.5)*cellDeg, ne.lat)
+		cells := make([]int, cols)
+		for col := range cells {
+			lng := normalizeLongitude(sw.lng + math.Min((float64(col)+0.5)*cellDeg, lngSpan))
+			cells[col], _ = index.nearestIndex(&Point{lat: lat, lng: lng})
+		}
+		labels = append(labels, cells)
+	}
+	return labels
+}
+
+// A CellEdge is the border between the cell at Row and Col of a raster and its neighbor,
+// the cell to the north if North is true, or the cell to the east otherwise.
+type CellEdge struct {
+	Row, Col int
+	North    bool
+}
+
+// Returns the borders between neighboring cells of the passed in raster, as returned by NearestSiteLabels,
+// whose labels differ.  The edges are ordered row by row from the south-west, west to east within each row.
+func SiteBoundaries(labels [][]int) []CellEdge {
+	edges := []CellEdge{}
+	for row := range labels {
+		for col, label := range labels[row] {
+			if col+1 < len(labels[row]) && labels[row][col+1] != label {
+				edges = append(edges, CellEdge{Row: row, Col: col})
+			}
+			if row+1 < len(labels) && col < len(labels[row+1]) && labels[row+1][col] != label {
+				edges = append(edges, CellEdge{Row: row, Col: col, North: true})
+			}
+		}
+	}
+	return edges
+}
+
+// A latitudeIndex holds points sorted by latitude, to find the nearest one without checking them all.
+type latitudeIndex struct {
+	points  []*Point
+	indices []int
+}
+
+// Returns a latitudeIndex over the passed in points, which are not modified.
+func newLatitudeIndex(points []*Point) *latitudeIndex {
+	idx := &latitudeIndex{points: append([]*Point(nil), points...), indices: make([]int, len(points))}
+	for i := range idx.indices {
+		idx.indices[i] = i
+	}
+	sort.Sort(idx)
+	return idx
+}
+
+func (idx *latitudeIndex) Len() int           { return len(idx.points) }
+func (idx *latitudeIndex) Less(i, j int) bool { return idx.points[i].lat < idx.points[j].lat }
+func (idx *latitudeIndex) Swap(i, j int) {
+	idx.points[i], idx.points[j] = idx.points[j], idx.points[i]
+	idx.indices[i], idx.indices[j] = idx.indices[j], idx.indices[i]
+}
+
+// Returns the great circle distance (in sea miles) from the passed in point to the nearest indexed point,
+// or +Inf if the index is empty.
+func (idx *latitudeIndex) nearest(p *Point) float64 {
+	_, dist := idx.nearestIndex(p)
+	return dist
+}
+
+// Returns the index, in the slice the index was built from, of the point nearest to the passed in point
+// and its great circle distance (in sea miles), preferring the lowest index among equally near points.
+// Returns -1 and +Inf if the index is empty.  Since two points are at least as far apart as their difference
+// in latitude, the search walks outwards from the latitude of the passed in point and stops once that bound
+// exceeds the best match.
+func (idx *latitudeIndex) nearestIndex(p *Point) (int, float64) {
+	best, bestDist := -1, math.Inf(1)
+	consider := func(i int) {
+		dist := p.GreatCircleDistance(idx.points[i])
+		if dist < bestDist || (dist == bestDist && idx.indices[i] < best) {
+			best, bestDist = idx.indices[i], dist
+		}
+	}
+
+	above := sort.Search(len(idx.points), func(i int) bool { return idx.points[i].lat >= p.lat })
+	below := above - 1
+	for below >= 0 || above < len(idx.points) {
+		if above < len(idx.points) && (below < 0 || idx.points[above].lat-p.lat <= p.lat-idx.points[below].lat) {
+			if latitudeDistance(idx.points[above].lat-p.lat) > bestDist {
+				break
+			}
+			consider(above)
+			above++
+		} else {
+			if latitudeDistance(p.lat-idx.points[below].lat) > bestDist {
+				break
+			}
+			consider(below)
+			below--
+		}
+	}
+	return best, bestDist
+}
+
+// Returns the distance (in sea miles) along a meridian spanning the passed in difference in latitude.
+func latitudeDistance(dLat float64) float64 {
+	return EARTHRADIUS * dLat * math.Pi / 180.0
+}
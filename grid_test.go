@@ -0,0 +1,233 @@
+package geo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Ensures that the grid counts, lists and iterates the same points, all inside of the box.
+func TestPointGrid(t *testing.T) {
+	sw := NewPoint(10, 20)
+	ne := NewPoint(12, 23)
+	grid := NewPointGrid(sw, ne, 0.5, 0.25)
+
+	points := grid.Points()
+	if grid.Count() != len(points) {
+		t.Errorf("Expected Count() to match the %d points, but got %d instead", len(points), grid.Count())
+	}
+	if grid.Count() != 5*13 {
+		t.Errorf("Expected %d points, but got %d instead", 5*13, grid.Count())
+	}
+
+	for _, p := range points {
+		if p.lat < sw.lat || p.lat > ne.lat || p.lng < sw.lng || p.lng > ne.lng {
+			t.Errorf("Expected %v to be inside of the box", p)
+		}
+	}
+
+	if first, last := points[0], points[len(points)-1]; *first != *sw || *last != *ne {
+		t.Errorf("Expected the grid to run from %v to %v, but got %v to %v instead", sw, ne, first, last)
+	}
+
+	for i := 0; ; i++ {
+		p, ok := grid.Next()
+		if !ok {
+			if i != len(points) {
+				t.Errorf("Expected Next() to return %d points, but got %d instead", len(points), i)
+			}
+			break
+		}
+		if *p != *points[i] {
+			t.Errorf("Expected point %d to be %v, but got %v instead", i, points[i], p)
+		}
+	}
+
+	grid.Reset()
+	if p, ok := grid.Next(); !ok || *p != *sw {
+		t.Errorf("Expected Reset() to restart the iteration at %v, but got %v instead", sw, p)
+	}
+}
+
+// Ensures that a grid crossing the antimeridian wraps the longitudes.
+func TestPointGridAcrossAntimeridian(t *testing.T) {
+	grid := NewPointGrid(NewPoint(0, 179), NewPoint(1, -179), 1, 1)
+
+	if grid.Count() != 6 {
+		t.Fatalf("Expected 6 points, but got %d instead", grid.Count())
+	}
+
+	expected := []float64{179, -180, -179}
+	for i, p := range grid.Points()[:3] {
+		if p.lng != expected[i] {
+			t.Errorf("Expected point %d to have longitude %f, but got %f instead", i, expected[i], p.lng)
+		}
+	}
+}
+
+// Ensures that the filter returns only the matching points and that Next does not allocate.
+func TestPointGridFilter(t *testing.T) {
+	grid := NewPointGrid(NewPoint(0, 0), NewPoint(10, 10), 1, 1)
+
+	north := grid.Filter(func(p *Point) bool { return p.lat > 5 })
+	if len(north) != 5*11 {
+		t.Errorf("Expected %d points, but got %d instead", 5*11, len(north))
+	}
+	for _, p := range north {
+		if p.lat <= 5 {
+			t.Errorf("Expected %v to match the filter", p)
+		}
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, ok := grid.Next(); !ok {
+			grid.Reset()
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Expected Next() not to allocate, but got %v allocations instead", allocs)
+	}
+}
+
+// Ensures that points are counted in the cells they fall into and that points outside are ignored.
+func TestHeatmapGrid(t *testing.T) {
+	points := []*Point{
+		NewPoint(0.5, 0.5), NewPoint(0.6, 0.9), // south-west cell
+		NewPoint(9.5, 9.5),               // north-east cell
+		NewPoint(10, 10),                 // north-east corner
+		NewPoint(5.5, 2.5),               // row 2, column 1
+		NewPoint(-1, 5), NewPoint(5, 11), // outside
+	}
+
+	counts := HeatmapGrid(points, NewPoint(0, 0), NewPoint(10, 10), 4, 5)
+	if len(counts) != 4 || len(counts[0]) != 5 {
+		t.Fatalf("Expected a 4x5 grid, but got %v instead", counts)
+	}
+
+	expected := map[[2]int]int{{0, 0}: 2, {3, 4}: 2, {2, 1}: 1}
+	for row := range counts {
+		for col, count := range counts[row] {
+			if count != expected[[2]int{row, col}] {
+				t.Errorf("Expected %d points in cell [%d, %d], but got %d instead", expected[[2]int{row, col}], row, col, count)
+			}
+		}
+	}
+}
+
+// Ensures that heatmaps work across the antimeridian.
+func TestHeatmapGridAcrossAntimeridian(t *testing.T) {
+	points := []*Point{NewPoint(0, 175), NewPoint(0, -175), NewPoint(0, -171), NewPoint(0, 0)}
+	counts := HeatmapGrid(points, NewPoint(-10, 170), NewPoint(10, -170), 1, 2)
+
+	if counts[0][0] != 1 || counts[0][1] != 2 {
+		t.Errorf("Expected [[1 2]], but got %v instead", counts)
+	}
+}
+
+// Ensures that the distance field of a single origin at the center of the box is smallest at the center.
+func TestDistanceField(t *testing.T) {
+	field := DistanceField([]*Point{NewPoint(5, 5)}, NewPoint(0, 0), NewPoint(10, 10), 5, 5)
+	if len(field) != 5 || len(field[0]) != 5 {
+		t.Fatalf("Expected a 5x5 grid, but got %v instead", field)
+	}
+
+	if field[2][2] > 1e-9 {
+		t.Errorf("Expected a distance of 0 at the center, but got %v instead", field[2][2])
+	}
+	for row := range field {
+		for col, dist := range field[row] {
+			if (row != 2 || col != 2) && dist <= field[2][2] {
+				t.Errorf("Expected cell [%d, %d] to be further away than the center, but got %v instead", row, col, dist)
+			}
+		}
+	}
+	if expected := NewPoint(1, 1).GreatCircleDistance(NewPoint(5, 5)); math.Abs(field[0][0]-expected) > 1e-9 {
+		t.Errorf("Expected a distance of %v in the south-west cell, but got %v instead", expected, field[0][0])
+	}
+}
+
+// Ensures that the distance field matches a brute force search over all origins.
+func TestDistanceFieldMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	origins := []*Point{}
+	for i := 0; i < 50; i++ {
+		origins = append(origins, NewPoint(r.Float64()*60-30, r.Float64()*360-180))
+	}
+
+	sw, ne := NewPoint(-40, 150), NewPoint(40, -150)
+	field := DistanceField(origins, sw, ne, 8, 12)
+	for row := range field {
+		for col, dist := range field[row] {
+			lng := 150 + (float64(col)+0.5)*5
+			if lng >= 180 {
+				lng -= 360
+			}
+			center := NewPoint(-40+(float64(row)+0.5)*10, lng)
+			expected := math.Inf(1)
+			for _, origin := range origins {
+				expected = math.Min(expected, center.GreatCircleDistance(origin))
+			}
+			if math.Abs(dist-expected) > 1e-9 {
+				t.Errorf("Expected a distance of %v in cell [%d, %d], but got %v instead", expected, row, col, dist)
+			}
+		}
+	}
+
+	if field := DistanceField(nil, sw, ne, 1, 1); !math.IsInf(field[0][0], 1) {
+		t.Errorf("Expected +Inf without any origins, but got %v instead", field[0][0])
+	}
+}
+
+// Ensures that the boundary between two sites falls along their perpendicular bisector.
+func TestNearestSiteLabels(t *testing.T) {
+	sites := []*Point{NewPoint(0, -5), NewPoint(0, 5)}
+	labels := NearestSiteLabels(sites, NewPoint(-10, -10), NewPoint(10, 10), 1)
+	if len(labels) != 20 || len(labels[0]) != 20 {
+		t.Fatalf("Expected a 20x20 raster, but got %dx%d instead", len(labels), len(labels[0]))
+	}
+
+	edges := SiteBoundaries(labels)
+	if len(edges) != 20 {
+		t.Errorf("Expected one edge per row, but got %d instead", len(edges))
+	}
+	for _, edge := range edges {
+		if edge.North || edge.Col != 9 {
+			t.Errorf("Expected the boundary to run along the prime meridian, but got %+v instead", edge)
+		}
+	}
+
+	sites = []*Point{NewPoint(20, 10), NewPoint(-5, 30)}
+	labels = NearestSiteLabels(sites, NewPoint(-20, 0), NewPoint(40, 40), 0.5)
+	edges = SiteBoundaries(labels)
+	if len(edges) == 0 {
+		t.Fatal("Expected a boundary between the sites")
+	}
+	for _, edge := range edges {
+		lat, lng := -20+float64(edge.Row+1)*0.5, 0+float64(edge.Col)*0.5+0.25
+		if !edge.North {
+			lat, lng = -20+float64(edge.Row)*0.5+0.25, 0+float64(edge.Col+1)*0.5
+		}
+		border := NewPoint(lat, lng)
+		if math.Abs(border.GreatCircleDistance(sites[0])-border.GreatCircleDistance(sites[1])) > 2*latitudeDistance(0.5) {
+			t.Errorf("Expected edge %+v to be within one cell of the bisector", edge)
+		}
+	}
+}
+
+// Ensures that ties are broken by the lowest index and that a raster without sites is unlabeled.
+func TestNearestSiteLabelsTies(t *testing.T) {
+	sites := []*Point{NewPoint(1, 1), NewPoint(5, 5), NewPoint(5, 5)}
+	labels := NearestSiteLabels(sites, NewPoint(4, 4), NewPoint(6, 6), 1)
+	for row := range labels {
+		for col, label := range labels[row] {
+			if label != 1 {
+				t.Errorf("Expected cell [%d, %d] to be labeled 1, but got %d instead", row, col, label)
+			}
+		}
+	}
+
+	labels = NearestSiteLabels(nil, NewPoint(4, 4), NewPoint(6, 6), 1)
+	if labels[0][0] != -1 {
+		t.Errorf("Expected -1 without any sites, but got %d instead", labels[0][0])
+	}
+}
@@ -0,0 +1,49 @@
+package geo
+
+import (
+	"math"
+)
+
+// Returns the axial coordinates of the cell of a grid of pointy-topped hexagons containing 'this' point.
+// The grid is laid out on an equirectangular projection centered on the passed in origin, where the cell (0, 0)
+// is centered, with hexagons whose corners are the passed in size (in sea miles) away from their centers.
+// q counts the cells towards the east, r the rows of cells towards the north-east.
+// The projection is only accurate close to the origin, within a few hundred sea miles.
+func (p *Point) HexCell(size float64, origin *Point) (q, r int) {
+	x, y := hexProjection(p, origin)
+	fq := (math.Sqrt(3)/3*x - y/3) / size
+	fr := (2.0 / 3.0 * y) / size
+	return hexRound(fq, fr)
+}
+
+// Returns the center of the hexagonal cell with the passed in axial coordinates,
+// in the grid described by HexCell.
+func HexCellCenter(q, r int, size float64, origin *Point) *Point {
+	x := size * (math.Sqrt(3)*float64(q) + math.Sqrt(3)/2*float64(r))
+	y := size * (1.5 * float64(r))
+
+	lat := origin.lat + y/EARTHRADIUS*180.0/math.Pi
+	lng := origin.lng + x/(EARTHRADIUS*math.Cos(origin.lat*math.Pi/180.0))*180.0/math.Pi
+	return NewPoint(lat, normalizeLongitude(lng))
+}
+
+// Returns the coordinates (in sea miles) of the passed in point on an equirectangular projection centered on the passed in origin.
+func hexProjection(p, origin *Point) (x, y float64) {
+	x = EARTHRADIUS * ShortestLongitudeDelta(origin.lng, p.lng) * math.Pi / 180.0 * math.Cos(origin.lat*math.Pi/180.0)
+	y = EARTHRADIUS * (p.lat - origin.lat) * math.Pi / 180.0
+	return x, y
+}
+
+// Rounds fractional axial coordinates to those of the hexagon containing them,
+// by rounding the cube coordinates and fixing up the one which changed the most.
+func hexRound(fq, fr float64) (q, r int) {
+	fs := -fq - fr
+	rq, rr, rs := math.Round(fq), math.Round(fr), math.Round(fs)
+	dq, dr, ds := math.Abs(rq-fq), math.Abs(rr-fr), math.Abs(rs-fs)
+	if dq > dr && dq > ds {
+		rq = -rr - rs
+	} else if dr > ds {
+		rr = -rq - rs
+	}
+	return int(rq), int(rr)
+}
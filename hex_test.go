@@ -0,0 +1,53 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Ensures that the centers of hexagonal cells map back onto their cells, also across the antimeridian.
+func TestHexCellCenter(t *testing.T) {
+	for _, origin := range []*Point{NewPoint(47.6, -122.3), NewPoint(-10, 179.9)} {
+		if q, r := origin.HexCell(5, origin); q != 0 || r != 0 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected the origin in cell (0, 0), but got (%d, %d)", q, r))
+		}
+		for q := -5; q <= 5; q++ {
+			for r := -5; r <= 5; r++ {
+				center := HexCellCenter(q, r, 5, origin)
+				if cq, cr := center.HexCell(5, origin); cq != q || cr != r {
+					t.Error("Unnacceptable result.", fmt.Sprintf("Expected the center %v in cell (%d, %d), but got (%d, %d)", center, q, r, cq, cr))
+				}
+			}
+		}
+	}
+}
+
+// Ensures that points within a hexagon map to its cell, and points beyond its edges to the neighboring cells.
+func TestHexCell(t *testing.T) {
+	origin := NewPoint(47.6, -122.3)
+	size := 5.0
+	center := HexCellCenter(2, -1, size, origin)
+
+	// Moves the passed in distance (in sea miles) from the center at the passed in angle from east
+	offset := func(dist, angle float64) *Point {
+		dx, dy := dist*math.Cos(angle), dist*math.Sin(angle)
+		return NewPoint(center.lat+dy/EARTHRADIUS*180/math.Pi, center.lng+dx/(EARTHRADIUS*math.Cos(origin.lat*math.Pi/180))*180/math.Pi)
+	}
+
+	// The edges of a pointy-topped hexagon are closest to its center towards the east, north-east, ...
+	inradius := size * math.Sqrt(3) / 2
+	neighbors := [][2]int{{3, -1}, {2, 0}, {1, 0}, {1, -1}, {2, -2}, {3, -2}}
+	for i := 0; i < 36; i++ {
+		angle := float64(i) * 10 * math.Pi / 180
+		if q, r := offset(inradius*0.95, angle).HexCell(size, origin); q != 2 || r != -1 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected a point at %d degrees within the hexagon in cell (2, -1), but got (%d, %d)", i*10, q, r))
+		}
+	}
+	for i, neighbor := range neighbors {
+		angle := float64(i) * 60 * math.Pi / 180
+		if q, r := offset(inradius*1.05, angle).HexCell(size, origin); q != neighbor[0] || r != neighbor[1] {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected a point beyond edge %d in cell %v, but got (%d, %d)", i, neighbor, q, r))
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// Returned by IDW when no sample lies within the search radius of the target.
+var ErrNoSamplesInRange = errors.New("no samples within range")
+
+// Estimates the value of a field at the passed in target by inverse distance weighting
+// of the passed in samples and their values, using great circle distances raised to the passed in power.
+// Only the maxNeighbors closest samples within maxRadius (in sea miles) are used,
+// all samples within maxRadius if maxNeighbors is 0.  If the target coincides with a sample,
+// that sample's value is returned as is.  Returns ErrNoSamplesInRange if no sample is close enough.
+func IDW(target *Point, samples []*Point, values []float64, power float64, maxNeighbors int, maxRadius float64) (float64, error) {
+	if len(samples) != len(values) {
+		return 0, errors.New("the number of samples and values differ")
+	}
+
+	type neighbor struct {
+		dist  float64
+		value float64
+	}
+	neighbors := []neighbor{}
+	for i, sample := range samples {
+		dist := target.GreatCircleDistance(sample)
+		if dist < 1e-9 {
+			return values[i], nil
+		}
+		if dist <= maxRadius {
+			neighbors = append(neighbors, neighbor{dist, values[i]})
+		}
+	}
+	if len(neighbors) == 0 {
+		return 0, ErrNoSamplesInRange
+	}
+
+	if maxNeighbors > 0 && len(neighbors) > maxNeighbors {
+		sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].dist < neighbors[j].dist })
+		neighbors = neighbors[:maxNeighbors]
+	}
+
+	sum, weights := 0.0, 0.0
+	for _, n := range neighbors {
+		weight := 1 / math.Pow(n.dist, power)
+		sum += weight * n.value
+		weights += weight
+	}
+	return sum / weights, nil
+}
+
+// Estimates the value of a field over the box spanned by the passed in south-west and north-east corners,
+// divided into square cells of the passed in size (in degrees), using IDW at the center of each cell.
+// The estimates are indexed by row and then column, with row 0 at the southern and column 0 at the western edge.
+// Cells without any sample in range are NaN.
+// If the north-east corner lies west of the south-west corner, the box is considered to cross the antimeridian.
+func IDWGrid(sw, ne *Point, cellDeg float64, samples []*Point, values []float64, power float64, maxNeighbors int, maxRadius float64) ([][]float64, error) {
+	if len(samples) != len(values) {
+		return nil, errors.New("the number of samples and values differ")
+	}
+	if cellDeg <= 0 {
+		return nil, errors.New("cell size must be positive")
+	}
+
+	lngSpan := ne.lng - sw.lng
+	if lngSpan < 0 {
+		lngSpan += 360
+	}
+	rows := int(math.Ceil((ne.lat-sw.lat)/cellDeg - 1e-9))
+	cols := int(math.Ceil(lngSpan/cellDeg - 1e-9))
+
+	grid := make([][]float64, 0, rows)
+	for row := 0; row < rows; row++ {
+		lat := math.Min(sw.lat+(float64(row)+0.5)*cellDeg, ne.lat)
+		estimates := make([]float64, cols)
+		for col := range estimates {
+			lng := normalizeLongitude(sw.lng + math.Min((float64(col)+0.5)*cellDeg, lngSpan))
+			estimate, err := IDW(NewPoint(lat, lng), samples, values, power, maxNeighbors, maxRadius)
+			if err == ErrNoSamplesInRange {
+				estimate = math.NaN()
+			}
+			estimates[col] = estimate
+		}
+		grid = append(grid, estimates)
+	}
+	return grid, nil
+}
@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// A linear field over latitude and longitude.
+func linearField(p *Point) float64 {
+	return 10 + 2*p.lat - 3*p.lng
+}
+
+// Returns samples of the linear field on a regular grid around the origin.
+func linearFieldSamples() ([]*Point, []float64) {
+	samples := NewPointGrid(NewPoint(-2, -2), NewPoint(2, 2), 0.5, 0.5).Points()
+	values := make([]float64, len(samples))
+	for i, sample := range samples {
+		values[i] = linearField(sample)
+	}
+	return samples, values
+}
+
+// Ensures that interpolating a linear field stays close to it.
+func TestIDW(t *testing.T) {
+	samples, values := linearFieldSamples()
+
+	for _, target := range []*Point{NewPoint(0.25, 0.25), NewPoint(-1.1, 0.7), NewPoint(1.3, -1.6)} {
+		estimate, err := IDW(target, samples, values, 2, 4, 100)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		// Within the spread of the four closest samples
+		if math.Abs(estimate-linearField(target)) > 2.5 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Estimated %f at %v, expected about %f", estimate, target, linearField(target)))
+		}
+	}
+
+	// Symmetric neighbours average out exactly
+	estimate, _ := IDW(NewPoint(0.25, 0.25), samples, values, 2, 4, 100)
+	if math.Abs(estimate-linearField(NewPoint(0.25, 0.25))) > 0.01 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Estimated %f at the center of a cell", estimate))
+	}
+}
+
+// Ensures that coinciding samples are returned as is, and that an empty neighborhood is an error.
+func TestIDWSpecialCases(t *testing.T) {
+	samples, values := linearFieldSamples()
+
+	estimate, err := IDW(NewPoint(0.5, -1), samples, values, 2, 0, 100)
+	if err != nil || estimate != linearField(NewPoint(0.5, -1)) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the sample value, got %f, %v", estimate, err))
+	}
+
+	if _, err := IDW(NewPoint(10, 10), samples, values, 2, 0, 100); err != ErrNoSamplesInRange {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected ErrNoSamplesInRange, got %v", err))
+	}
+	if _, err := IDW(NewPoint(0, 0), samples, values[1:], 2, 0, 100); err == nil {
+		t.Error("Expected an error for mismatched samples and values")
+	}
+}
+
+// Ensures that the grid holds an estimate for every cell in range and NaN elsewhere.
+func TestIDWGrid(t *testing.T) {
+	samples, values := linearFieldSamples()
+
+	grid, err := IDWGrid(NewPoint(-1, -1), NewPoint(1, 3), 0.5, samples, values, 2, 4, 40)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	if len(grid) != 4 || len(grid[0]) != 8 {
+		t.Fatal("Unnacceptable result.", fmt.Sprintf("Expected a 4x8 grid, got %v", grid))
+	}
+
+	for row := range grid {
+		for col, estimate := range grid[row] {
+			center := NewPoint(-1+(float64(row)+0.5)*0.5, -1+(float64(col)+0.5)*0.5)
+			if center.lng > 2.5 {
+				if !math.IsNaN(estimate) {
+					t.Error("Unnacceptable result.", fmt.Sprintf("Expected NaN at %v, got %f", center, estimate))
+				}
+			} else if math.Abs(estimate-linearField(center)) > 2.5 {
+				t.Error("Unnacceptable result.", fmt.Sprintf("Estimated %f at %v, expected about %f", estimate, center, linearField(center)))
+			}
+		}
+	}
+}
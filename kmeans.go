@@ -0,0 +1,129 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// Partitions the passed in points into k clusters by k-means, and returns the centroid of each cluster along with
+// the index of the cluster of each point.  The centroids are seeded by k-means++ using the passed in source of
+// randomness, or a fixed seed if it is nil.  Points are assigned to their nearest centroid by great circle distance,
+// and centroids are averaged as position vectors on the unit sphere, so that clusters spanning the antimeridian
+// or a pole converge correctly.  A cluster left empty is re-seeded with the point farthest from its centroid.
+// Stops after maxIter iterations, or as soon as no point changes its cluster.
+func KMeans(points []*Point, k int, maxIter int, r *rand.Rand) (centroids []*Point, assignment []int, err error) {
+	if k < 1 || k > len(points) {
+		return nil, nil, errors.New("Unable to cluster the points into fewer than one or more clusters than points")
+	}
+	if maxIter < 1 {
+		return nil, nil, errors.New("Unable to cluster the points in fewer than one iteration")
+	}
+	if r == nil {
+		r = rand.New(rand.NewSource(1))
+	}
+
+	centroids = kmeansSeeds(points, k, r)
+	assignment = make([]int, len(points))
+	for i := range assignment {
+		assignment[i] = -1
+	}
+	for iter := 0; iter < maxIter; iter++ {
+		changed := false
+		for i, p := range points {
+			if c := nearestCentroid(p, centroids); c != assignment[i] {
+				assignment[i] = c
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+		kmeansUpdate(points, centroids, assignment)
+	}
+	return centroids, assignment, nil
+}
+
+// Picks k of the passed in points as initial centroids by k-means++, each one with a probability
+// proportional to the square of its distance to the nearest centroid picked so far.
+func kmeansSeeds(points []*Point, k int, r *rand.Rand) []*Point {
+	first := points[r.Intn(len(points))]
+	centroids := []*Point{NewPoint(first.lat, first.lng)}
+	weights := make([]float64, len(points))
+	for len(centroids) < k {
+		total := 0.0
+		for i, p := range points {
+			dist := p.GreatCircleDistance(centroids[nearestCentroid(p, centroids)])
+			weights[i] = dist * dist
+			total += weights[i]
+		}
+
+		// All points coincide with a centroid, so any of them will do
+		next := r.Intn(len(points))
+		if total > 0 {
+			target := r.Float64() * total
+			for i, weight := range weights {
+				if target -= weight; target < 0 && weight > 0 {
+					next = i
+					break
+				}
+			}
+		}
+		centroids = append(centroids, NewPoint(points[next].lat, points[next].lng))
+	}
+	return centroids
+}
+
+// Returns the index of the centroid nearest to the passed in point, preferring the lowest index among equally near ones.
+func nearestCentroid(p *Point, centroids []*Point) int {
+	best, bestDist := 0, math.Inf(1)
+	for c, centroid := range centroids {
+		if dist := p.GreatCircleDistance(centroid); dist < bestDist {
+			best, bestDist = c, dist
+		}
+	}
+	return best
+}
+
+// Moves the passed in centroids to the centroids of the points assigned to them.  An empty cluster takes over
+// the point farthest from its own centroid, among those whose clusters have other points left, preferring the
+// lowest index among equally far ones.  A cluster whose points cancel each other out keeps its centroid.
+func kmeansUpdate(points []*Point, centroids []*Point, assignment []int) {
+	counts := make([]int, len(centroids))
+	for _, c := range assignment {
+		counts[c]++
+	}
+
+	for c := range centroids {
+		if counts[c] > 0 {
+			continue
+		}
+		farthest, farthestDist := -1, -1.0
+		for i, p := range points {
+			if counts[assignment[i]] < 2 {
+				continue
+			}
+			if dist := p.GreatCircleDistance(centroids[assignment[i]]); dist > farthestDist {
+				farthest, farthestDist = i, dist
+			}
+		}
+		if farthest < 0 {
+			continue
+		}
+		counts[assignment[farthest]]--
+		counts[c]++
+		assignment[farthest] = c
+	}
+
+	sums := make([][3]float64, len(centroids))
+	for i, p := range points {
+		x, y, z := p.toVector()
+		sum := &sums[assignment[i]]
+		sum[0], sum[1], sum[2] = sum[0]+x, sum[1]+y, sum[2]+z
+	}
+	for c, sum := range sums {
+		if counts[c] > 0 && math.Sqrt(sum[0]*sum[0]+sum[1]*sum[1]+sum[2]*sum[2])/float64(counts[c]) >= minResultant {
+			centroids[c] = pointFromVector(sum[0], sum[1], sum[2])
+		}
+	}
+}
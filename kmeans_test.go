@@ -0,0 +1,103 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// Returns points scattered within a degree around each of the passed in centers, and the index of each point's center.
+func kmeansBlobs(centers []*Point, size int, r *rand.Rand) ([]*Point, []int) {
+	points, truth := []*Point{}, []int{}
+	for c, center := range centers {
+		for i := 0; i < size; i++ {
+			lng := normalizeLongitude(center.lng + r.Float64()*2 - 1)
+			points = append(points, NewPoint(center.lat+r.Float64()*2-1, lng))
+			truth = append(truth, c)
+		}
+	}
+	return points, truth
+}
+
+// Ensures that k-means recovers well separated clusters, including one spanning the antimeridian, regardless of the seed.
+func TestKMeans(t *testing.T) {
+	centers := []*Point{NewPoint(0, 180), NewPoint(45, 10), NewPoint(-30, -60)}
+	points, truth := kmeansBlobs(centers, 30, rand.New(rand.NewSource(42)))
+
+	for seed := int64(0); seed < 20; seed++ {
+		centroids, assignment, err := KMeans(points, 3, 100, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(centroids) != 3 || len(assignment) != len(points) {
+			t.Fatalf("Expected 3 centroids and %d assignments, but got %v and %v", len(points), centroids, assignment)
+		}
+
+		// Each blob maps to its own cluster, whose centroid lies at the center of the blob
+		labels := map[int]int{}
+		for i, c := range assignment {
+			if label, ok := labels[truth[i]]; !ok {
+				labels[truth[i]] = c
+			} else if label != c {
+				t.Fatal("Unnacceptable result.", fmt.Sprintf("Expected blob %d to be one cluster with seed %d, but got %v", truth[i], seed, assignment))
+			}
+		}
+		if len(labels) != 3 || labels[0] == labels[1] || labels[1] == labels[2] || labels[0] == labels[2] {
+			t.Fatal("Unnacceptable result.", fmt.Sprintf("Expected each blob to be its own cluster with seed %d, but got %v", seed, labels))
+		}
+		for blob, c := range labels {
+			if dist := centroids[c].GreatCircleDistance(centers[blob]); dist > 30 {
+				t.Error("Unnacceptable result.", fmt.Sprintf("Expected the centroid of blob %d near %v, but got %v", blob, centers[blob], centroids[c]))
+			}
+		}
+	}
+}
+
+// Ensures that every distinct point becomes its own cluster when there are as many clusters as points.
+func TestKMeansDistinctPoints(t *testing.T) {
+	points := []*Point{NewPoint(0, 0), NewPoint(10, 10), NewPoint(-10, 170), NewPoint(50, -100)}
+	centroids, assignment, err := KMeans(points, 4, 10, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[int]bool{}
+	for i, c := range assignment {
+		seen[c] = true
+		if math.Abs(centroids[c].lat-points[i].lat) > 1e-9 || math.Abs(centroids[c].lng-points[i].lng) > 1e-9 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected point %v to be its own centroid, but got %v", points[i], centroids[c]))
+		}
+	}
+	if len(seen) != 4 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected 4 clusters, but got %v", assignment))
+	}
+}
+
+// Ensures that k-means rejects impossible cluster counts and iteration limits.
+func TestKMeansInvalid(t *testing.T) {
+	points := []*Point{NewPoint(0, 0), NewPoint(1, 1)}
+	if _, _, err := KMeans(points, 0, 10, nil); err == nil {
+		t.Error("Expected an error clustering into zero clusters")
+	}
+	if _, _, err := KMeans(points, 3, 10, nil); err == nil {
+		t.Error("Expected an error clustering into more clusters than points")
+	}
+	if _, _, err := KMeans(points, 1, 0, nil); err == nil {
+		t.Error("Expected an error clustering in zero iterations")
+	}
+}
+
+// Ensures that an empty cluster is re-seeded with the point farthest from its centroid.
+func TestKMeansReseed(t *testing.T) {
+	points := []*Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(0, 20), NewPoint(0, 2)}
+	centroids := []*Point{NewPoint(0, 0), NewPoint(80, 0)}
+	assignment := []int{0, 0, 0, 0}
+	kmeansUpdate(points, centroids, assignment)
+
+	if expected := []int{0, 0, 1, 0}; fmt.Sprint(assignment) != fmt.Sprint(expected) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v, but got %v", expected, assignment))
+	}
+	if centroids[1].lat != 0 || math.Abs(centroids[1].lng-20) > 1e-9 || math.Abs(centroids[0].lng-1) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected centroids at [0, 1] and [0, 20], but got %v", centroids))
+	}
+}
@@ -0,0 +1,119 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// A MeasuredPoint is a Point with the accuracy it was measured with, e.g. by a GPS receiver.
+type MeasuredPoint struct {
+	*Point
+	// The radius of uncertainty around the Point (in meters), 0 if unknown
+	Accuracy float64
+}
+
+// Creates and returns a new MeasuredPoint at the passed in coordinates
+// with the passed in accuracy (in meters).
+func NewMeasuredPoint(lat, lng, accuracy float64) MeasuredPoint {
+	return MeasuredPoint{Point: NewPoint(lat, lng), Accuracy: accuracy}
+}
+
+// Whether one MeasuredPoint is within a distance of another, as returned by MeasuredPoint.WithinDistance.
+type Proximity int
+
+const (
+	// The points are within the distance, even allowing for their accuracies
+	DefinitelyWithin Proximity = iota
+	// The points are beyond the distance, even allowing for their accuracies
+	DefinitelyNotWithin
+	// The points may or may not be within the distance
+	PossiblyWithin
+)
+
+// Returns the name of the proximity, e.g. "definitely within".
+func (p Proximity) String() string {
+	switch p {
+	case DefinitelyWithin:
+		return "definitely within"
+	case DefinitelyNotWithin:
+		return "definitely not within"
+	case PossiblyWithin:
+		return "possibly within"
+	default:
+		return "Proximity(" + strconv.Itoa(int(p)) + ")"
+	}
+}
+
+// Returns the combined accuracy of two independent measurements (in meters).
+func combinedAccuracy(a, b float64) float64 {
+	return math.Sqrt(a*a + b*b)
+}
+
+// Calculates the great circle distance to the passed in MeasuredPoint,
+// and its uncertainty as the root sum square of both accuracies.
+func (m MeasuredPoint) DistanceTo(other MeasuredPoint) (dist Distance, uncertainty Distance) {
+	return Distance(m.GreatCircleDistance(other.Point)), NewDistance(combinedAccuracy(m.Accuracy, other.Accuracy), Meters)
+}
+
+// Calculates the midpoint between the current and the passed in MeasuredPoint.
+// Its accuracy is that of the mean of two independent measurements.
+func (m MeasuredPoint) MidpointTo(other MeasuredPoint) MeasuredPoint {
+	return MeasuredPoint{
+		Point:    m.Point.MidpointTo(other.Point),
+		Accuracy: combinedAccuracy(m.Accuracy, other.Accuracy) / 2,
+	}
+}
+
+// Returns whether the passed in MeasuredPoint is within the passed in distance
+// of the current one, allowing for the uncertainty of their distance.
+func (m MeasuredPoint) WithinDistance(other MeasuredPoint, threshold Distance) Proximity {
+	dist, uncertainty := m.DistanceTo(other)
+	switch {
+	case dist+uncertainty <= threshold:
+		return DefinitelyWithin
+	case dist-uncertainty > threshold:
+		return DefinitelyNotWithin
+	default:
+		return PossiblyWithin
+	}
+}
+
+// Renders the current MeasuredPoint to JSON like Point.MarshalJSON,
+// with the accuracy in an additional "acc" key if it is known.
+// A MeasuredPoint without a Point is rendered as null.
+// Implements the json.Marshaller Interface.
+func (m MeasuredPoint) MarshalJSON() ([]byte, error) {
+	if m.Point == nil {
+		return []byte("null"), nil
+	}
+	data, err := m.Point.MarshalJSON()
+	if err != nil || m.Accuracy == 0 {
+		return data, err
+	}
+	return []byte(fmt.Sprintf(`%s, "acc":%v}`, data[:len(data)-1], m.Accuracy)), nil
+}
+
+// Decodes the current MeasuredPoint from JSON rendered by MarshalJSON.
+// Only the latitude, longitude and "acc" keys are decoded, other members are ignored.
+// Like for other types, null leaves the MeasuredPoint unchanged.
+func (m *MeasuredPoint) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil || members == nil {
+		return err
+	}
+
+	var lat, lng, acc float64
+	for key, value := range map[string]*float64{JSONFieldNames.Lat: &lat, JSONFieldNames.Lng: &lng, "acc": &acc} {
+		if raw, ok := members[key]; ok {
+			if err := json.Unmarshal(raw, value); err != nil {
+				return fmt.Errorf("Unable to decode %q of a measured point: %v", key, err)
+			}
+		}
+	}
+
+	m.Point = NewPoint(lat, lng)
+	m.Accuracy = acc
+	return nil
+}
@@ -0,0 +1,94 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Ensures that distances carry the combined uncertainty of both points.
+func TestMeasuredPointDistanceTo(t *testing.T) {
+	a := NewMeasuredPoint(47.6062, -122.3321, 30)
+	b := NewMeasuredPoint(47.6097, -122.3331, 40)
+
+	// The distance is in sea miles like GreatCircleDistance, the uncertainty of 50 m too
+	dist, uncertainty := a.DistanceTo(b)
+	if math.Abs(float64(dist)-a.GreatCircleDistance(b.Point)) > 1e-12 || math.Abs(uncertainty.In(Meters)-50) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Got %f ± %f", dist, uncertainty))
+	}
+
+	mid := a.MidpointTo(b)
+	if mid.Accuracy != 25 || mid.GreatCircleDistance(a.Point.MidpointTo(b.Point)) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Got %v ± %f", mid.Point, mid.Accuracy))
+	}
+}
+
+// Ensures that the proximity switches at the threshold plus and minus the uncertainty.
+func TestMeasuredPointWithinDistance(t *testing.T) {
+	a := NewMeasuredPoint(0, 0, 30)
+	// Accuracies of 30 m and 40 m make for an uncertainty of 50 m
+	at := func(meters float64) MeasuredPoint {
+		p := a.PointAtDistanceAndBearing(meters/metersPerSeaMile, 90)
+		return MeasuredPoint{Point: p, Accuracy: 40}
+	}
+
+	tests := []struct {
+		dist      float64
+		proximity Proximity
+	}{
+		{100, DefinitelyWithin},
+		{449, DefinitelyWithin},
+		{451, PossiblyWithin},
+		{500, PossiblyWithin},
+		{549, PossiblyWithin},
+		{551, DefinitelyNotWithin},
+	}
+
+	for _, test := range tests {
+		if proximity := a.WithinDistance(at(test.dist), NewDistance(500, Meters)); proximity != test.proximity {
+			t.Error("Unnacceptable result.", fmt.Sprintf("At %v m: %v, expected %v", test.dist, proximity, test.proximity))
+		}
+	}
+
+	exact := MeasuredPoint{Point: a.Point}
+	if proximity := exact.WithinDistance(MeasuredPoint{Point: at(499).Point}, NewDistance(500, Meters)); proximity != DefinitelyWithin {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Without uncertainty: %v", proximity))
+	}
+}
+
+// Ensures that the accuracy is marshaled only when it is known.
+func TestMeasuredPointJSON(t *testing.T) {
+	data, err := json.Marshal(NewMeasuredPoint(40.5, -120.5, 12.5))
+	if err != nil || string(data) != `{"lat":40.5,"lng":-120.5,"acc":12.5}` {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%s (%v)", data, err))
+	}
+
+	var m MeasuredPoint
+	if err := json.Unmarshal(data, &m); err != nil || m.Lat() != 40.5 || m.Lng() != -120.5 || m.Accuracy != 12.5 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%v ± %f (%v)", m.Point, m.Accuracy, err))
+	}
+
+	data, _ = json.Marshal(NewMeasuredPoint(40.5, -120.5, 0))
+	if string(data) != `{"lat":40.5,"lng":-120.5}` {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%s", data))
+	}
+
+	// A MeasuredPoint without a Point is null, and null leaves a MeasuredPoint as it is
+	data, err = json.Marshal(MeasuredPoint{})
+	if err != nil || string(data) != "null" {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%s (%v)", data, err))
+	}
+	if err := json.Unmarshal(data, &m); err != nil || m.Lat() != 40.5 || m.Accuracy != 12.5 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%v ± %f (%v)", m.Point, m.Accuracy, err))
+	}
+
+	// Other members are ignored, whatever their type
+	data = []byte(`{"lat":1.5,"lng":2.5,"acc":3,"source":"gps","fix":{"sats":7},"valid":true}`)
+	if err := json.Unmarshal(data, &m); err != nil || m.Lat() != 1.5 || m.Lng() != 2.5 || m.Accuracy != 3 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%v ± %f (%v)", m.Point, m.Accuracy, err))
+	}
+	if err := json.Unmarshal([]byte(`{"lat":"north","lng":2.5}`), &m); err == nil {
+		t.Error("Unnacceptable result.", "Expected an error for a latitude which is no number")
+	}
+}
@@ -0,0 +1,60 @@
+package geo
+
+import (
+	"math"
+	"time"
+)
+
+// The velocity of a moving object.
+type Velocity struct {
+	// The speed in knots (sea miles per hour)
+	Speed float64
+	// The compass bearing of the course (in degrees)
+	Heading float64
+}
+
+// Returns the Point reached from the passed in position after moving
+// with the passed in velocity for the passed in duration, by dead reckoning along the great circle.
+func deadReckon(p *Point, v Velocity, d time.Duration) *Point {
+	return p.PointAtDistanceAndBearing(v.Speed*d.Hours(), v.Heading)
+}
+
+// Calculates when, within the passed in horizon, two objects starting at p1 and p2 and moving with
+// the velocities v1 and v2 come closest to each other, and how far apart they are then (in sea miles).
+// The time of closest approach is found analytically from the relative motion in a local plane
+// centered between the objects, which is accurate as long as the objects stay within a few hundred
+// sea miles of each other.  The returned distance is the great circle distance between the
+// dead reckoned positions at that time.
+func ClosestApproach(p1 *Point, v1 Velocity, p2 *Point, v2 Velocity, horizon time.Duration) (timeToClosest time.Duration, dist float64) {
+	// Project onto a plane tangent at the midpoint, in sea miles east and north
+	mid := p1.MidpointTo(p2)
+	scale := EARTHRADIUS * math.Pi / 180.0
+	cosLat := math.Cos(mid.lat * math.Pi / 180.0)
+	x := ShortestLongitudeDelta(p1.lng, p2.lng) * cosLat * scale
+	y := (p2.lat - p1.lat) * scale
+
+	// The velocity of the second object relative to the first, in knots east and north
+	h1, h2 := v1.Heading*math.Pi/180.0, v2.Heading*math.Pi/180.0
+	vx := v2.Speed*math.Sin(h2) - v1.Speed*math.Sin(h1)
+	vy := v2.Speed*math.Cos(h2) - v1.Speed*math.Cos(h1)
+
+	hours := 0.0
+	if speed := vx*vx + vy*vy; speed > 0 {
+		hours = -(x*vx + y*vy) / speed
+	}
+	hours = math.Max(0, math.Min(hours, horizon.Hours()))
+
+	timeToClosest = time.Duration(hours * float64(time.Hour))
+	return timeToClosest, deadReckon(p1, v1, timeToClosest).GreatCircleDistance(deadReckon(p2, v2, timeToClosest))
+}
+
+// Returns a Polygon approximating the area reachable from 'this' point within the passed in duration
+// at the passed in constant speed (in knots), by the passed in number of vertices (at least 3).
+// The vertices lie at the reachable distance along great circles in evenly spaced directions,
+// starting due north and going clockwise, so that the shape accounts for the meridians converging near the poles.
+func (p *Point) Isochrone(speed float64, d time.Duration, segments int) *Polygon {
+	if segments < 3 {
+		segments = 3
+	}
+	return circlePolygon(p, speed*d.Hours(), segments)
+}
@@ -0,0 +1,66 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// Ensures that two objects converging head-on meet halfway after the expected time.
+func TestClosestApproachHeadOn(t *testing.T) {
+	// 60 sea miles apart along the equator, closing at 20 knots in total
+	p1 := NewPoint(0, 0)
+	p2 := NewPoint(0, 1.0*180.0/math.Pi*60.0/EARTHRADIUS)
+
+	when, dist := ClosestApproach(p1, Velocity{Speed: 12, Heading: 90}, p2, Velocity{Speed: 8, Heading: 270}, 6*time.Hour)
+	if math.Abs(when.Hours()-3) > 0.001 || dist > 0.01 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected to meet after 3h, got %v at %f", when, dist))
+	}
+
+	// With a shorter horizon the closest approach is at its end
+	when, dist = ClosestApproach(p1, Velocity{Speed: 12, Heading: 90}, p2, Velocity{Speed: 8, Heading: 270}, time.Hour)
+	if when != time.Hour || math.Abs(dist-40) > 0.01 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected 40 sea miles after 1h, got %v at %f", when, dist))
+	}
+}
+
+// Ensures that objects passing each other and diverging objects are handled.
+func TestClosestApproachMisses(t *testing.T) {
+	// Parallel courses 6 sea miles apart, in opposite directions, 30 sea miles apart in longitude
+	p1 := NewPoint(0, 0)
+	p2 := NewPoint(6.0/60.0, 30.0/60.0)
+	when, dist := ClosestApproach(p1, Velocity{Speed: 10, Heading: 90}, p2, Velocity{Speed: 20, Heading: 270}, 2*time.Hour)
+	if math.Abs(when.Hours()-1) > 0.01 || math.Abs(dist-6) > 0.05 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected to pass 6 sea miles apart after 1h, got %v at %f", when, dist))
+	}
+
+	// Moving apart, the closest approach is now
+	when, dist = ClosestApproach(p1, Velocity{Speed: 10, Heading: 270}, p2, Velocity{Speed: 10, Heading: 90}, time.Hour)
+	if when != 0 || math.Abs(dist-p1.GreatCircleDistance(p2)) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the closest approach now, got %v at %f", when, dist))
+	}
+}
+
+// Ensures that the vertices of an isochrone lie at the distance reachable in the given time.
+func TestIsochrone(t *testing.T) {
+	for _, center := range []*Point{NewPoint(40.7486, -73.9864), NewPoint(85, 120)} {
+		// 15 knots for 90 minutes
+		iso := center.Isochrone(15, 90*time.Minute, 36)
+		if len(iso.Points()) != 36 {
+			t.Fatalf("Expected 36 vertices, but got %d", len(iso.Points()))
+		}
+		for _, vertex := range iso.Points() {
+			if dist := center.GreatCircleDistance(vertex); math.Abs(dist-22.5) > 1e-6 {
+				t.Error("Unnacceptable result.", fmt.Sprintf("Expected vertex %v to be 22.5 sea miles from %v, but got %v", vertex, center, dist))
+			}
+		}
+		if !iso.Contains(center) {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected the isochrone to contain its center %v", center))
+		}
+	}
+
+	if iso := NewPoint(0, 0).Isochrone(10, time.Hour, 1); len(iso.Points()) != 3 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected at least 3 vertices, but got %v", iso.Points()))
+	}
+}
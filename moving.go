@@ -0,0 +1,297 @@
+package geo
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// The number of independently locked shards of a MovingIndex.
+const movingIndexShards = 16
+
+// A MovingIndex keeps track of the positions of moving entities by their ids, like couriers or vehicles,
+// and finds the ones near a point.  Entities are kept in a hash map of buckets by the geohash of their position,
+// so unlike a balanced tree which has to be rebalanced, an update only moves an entity between two buckets,
+// in constant time.  The price is paid by the queries which have to visit every bucket overlapping
+// the area searched: they are exact, but get slower for radii much larger than the buckets, for the large buckets
+// of crowded spots, and for Nearest in sparse areas, where it widens its search ring by ring.
+// A MovingIndex is safe for concurrent use, with updates and queries locking one shard of entities at a time,
+// so a query running concurrently with updates may or may not see each of them.
+type MovingIndex struct {
+	precision int
+	latDeg    float64
+	lngDeg    float64
+	latCells  int
+	lngCells  int
+	shards    [movingIndexShards]movingShard
+
+	// Returns the current time, replaceable in tests
+	now func() time.Time
+}
+
+// A shard of the entities of a MovingIndex.
+type movingShard struct {
+	sync.RWMutex
+	entries map[string]*movingEntry
+	buckets map[string]map[string]*movingEntry
+}
+
+// An entity of a MovingIndex.
+type movingEntry struct {
+	id      string
+	point   Point
+	bucket  string
+	updated time.Time
+}
+
+// Creates and returns a pointer to a new, empty MovingIndex bucketing entities by geohashes of the passed in precision,
+// clamped to between 1 and 12.  The buckets should be about as large as the radii searched, e.g. a precision of 5
+// for buckets of about 2.6 by 2.6 sea miles at the equator suits searches within a few sea miles.
+func NewMovingIndex(precision int) *MovingIndex {
+	precision = int(math.Max(1, math.Min(12, float64(precision))))
+	latDeg, lngDeg := geohashCellSize(precision)
+	mi := &MovingIndex{
+		precision: precision,
+		latDeg:    latDeg,
+		lngDeg:    lngDeg,
+		latCells:  int(math.Round(180 / latDeg)),
+		lngCells:  int(math.Round(360 / lngDeg)),
+		now:       time.Now,
+	}
+	for i := range mi.shards {
+		mi.shards[i].entries = map[string]*movingEntry{}
+		mi.shards[i].buckets = map[string]map[string]*movingEntry{}
+	}
+	return mi
+}
+
+// Returns the shard holding the entity with the passed in id.
+func (mi *MovingIndex) shard(id string) *movingShard {
+	h := fnv.New32a()
+	h.Write([]byte(id))
+	return &mi.shards[h.Sum32()%movingIndexShards]
+}
+
+// Returns the row and column of the bucket containing the passed in coordinates.
+func (mi *MovingIndex) cell(lat, lng float64) (row, col int) {
+	row = int(math.Floor((lat + 90) / mi.latDeg))
+	col = int(math.Floor((normalizeLongitude(lng) + 180) / mi.lngDeg))
+	return int(math.Max(0, math.Min(float64(mi.latCells-1), float64(row)))), col % mi.lngCells
+}
+
+// Returns the geohash of the bucket in the passed in row and column, wrapping the column around the antimeridian.
+func (mi *MovingIndex) bucket(row, col int) string {
+	col = ((col % mi.lngCells) + mi.lngCells) % mi.lngCells
+	return geohashEncode(-90+(float64(row)+0.5)*mi.latDeg, -180+(float64(col)+0.5)*mi.lngDeg, mi.precision)
+}
+
+// Sets the position of the entity with the passed in id, adding it if it is new, and records the time of the update.
+func (mi *MovingIndex) Update(id string, p *Point) {
+	bucket := mi.bucket(mi.cell(p.lat, p.lng))
+	now := mi.now()
+
+	s := mi.shard(id)
+	s.Lock()
+	defer s.Unlock()
+
+	entry, ok := s.entries[id]
+	if !ok {
+		entry = &movingEntry{id: id}
+		s.entries[id] = entry
+	}
+	if entry.bucket != bucket {
+		if ok {
+			s.removeFromBucket(entry)
+		}
+		entry.bucket = bucket
+		if s.buckets[bucket] == nil {
+			s.buckets[bucket] = map[string]*movingEntry{}
+		}
+		s.buckets[bucket][id] = entry
+	}
+	entry.point = *p
+	entry.updated = now
+}
+
+// Removes the entity with the passed in id, if there is one.
+func (mi *MovingIndex) Remove(id string) {
+	s := mi.shard(id)
+	s.Lock()
+	defer s.Unlock()
+
+	if entry, ok := s.entries[id]; ok {
+		s.removeFromBucket(entry)
+		delete(s.entries, id)
+	}
+}
+
+// Removes the passed in entry from its bucket, dropping the bucket once it is empty.
+func (s *movingShard) removeFromBucket(entry *movingEntry) {
+	delete(s.buckets[entry.bucket], entry.id)
+	if len(s.buckets[entry.bucket]) == 0 {
+		delete(s.buckets, entry.bucket)
+	}
+}
+
+// Returns the number of entities in the index.
+func (mi *MovingIndex) Len() int {
+	n := 0
+	for i := range mi.shards {
+		mi.shards[i].RLock()
+		n += len(mi.shards[i].entries)
+		mi.shards[i].RUnlock()
+	}
+	return n
+}
+
+// Returns the ids of the entities which have not been updated for longer than the passed in duration, sorted,
+// e.g. to remove couriers which went offline.
+func (mi *MovingIndex) Stale(olderThan time.Duration) []string {
+	cutoff := mi.now().Add(-olderThan)
+	ids := []string{}
+	for i := range mi.shards {
+		s := &mi.shards[i]
+		s.RLock()
+		for id, entry := range s.entries {
+			if entry.updated.Before(cutoff) {
+				ids = append(ids, id)
+			}
+		}
+		s.RUnlock()
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Returns the number of non-empty buckets, across all shards.
+func (mi *MovingIndex) buckets() int {
+	n := 0
+	for i := range mi.shards {
+		mi.shards[i].RLock()
+		n += len(mi.shards[i].buckets)
+		mi.shards[i].RUnlock()
+	}
+	return n
+}
+
+// Calls the passed in function for each entity in the buckets with the passed in geohashes,
+// or for every entity if buckets is nil.
+func (mi *MovingIndex) visit(buckets map[string]bool, fn func(entry *movingEntry)) {
+	for i := range mi.shards {
+		s := &mi.shards[i]
+		s.RLock()
+		if buckets == nil {
+			for _, entry := range s.entries {
+				fn(entry)
+			}
+		} else {
+			for bucket := range buckets {
+				for _, entry := range s.buckets[bucket] {
+					fn(entry)
+				}
+			}
+		}
+		s.RUnlock()
+	}
+}
+
+// Returns the ids of the entities within the passed in radius (in sea miles) of the passed in point,
+// sorted by their distance.
+func (mi *MovingIndex) Within(p *Point, radius float64) []string {
+	type match struct {
+		id   string
+		dist float64
+	}
+	matches := []match{}
+	mi.visit(mi.bucketsWithin(p, radius), func(entry *movingEntry) {
+		if dist := p.GreatCircleDistance(&entry.point); dist <= radius {
+			matches = append(matches, match{entry.id, dist})
+		}
+	})
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].dist != matches[j].dist {
+			return matches[i].dist < matches[j].dist
+		}
+		return matches[i].id < matches[j].id
+	})
+	ids := make([]string, len(matches))
+	for i, m := range matches {
+		ids[i] = m.id
+	}
+	return ids
+}
+
+// Returns the geohashes of the buckets overlapping the bounding box of the circle with the passed in center
+// and radius (in sea miles), or nil if there are more of them than non-empty buckets, so that visiting
+// all entities is cheaper.
+func (mi *MovingIndex) bucketsWithin(center *Point, radius float64) map[string]bool {
+	minLat, minLng, maxLat, maxLng := circleBounds(center, radius)
+	minRow, _ := mi.cell(math.Max(minLat, -90), 0)
+	maxRow, _ := mi.cell(math.Min(maxLat, 90), 0)
+
+	cols := mi.lngCells
+	minCol := 0
+	if maxLng-minLng < 360 {
+		minCol = int(math.Floor((minLng + 180) / mi.lngDeg))
+		cols = int(math.Min(float64(mi.lngCells), float64(int(math.Floor((maxLng+180)/mi.lngDeg))-minCol+1)))
+	}
+	if (maxRow-minRow+1)*cols > mi.buckets() {
+		return nil
+	}
+
+	buckets := make(map[string]bool, (maxRow-minRow+1)*cols)
+	for row := minRow; row <= maxRow; row++ {
+		for col := minCol; col < minCol+cols; col++ {
+			buckets[mi.bucket(row, col)] = true
+		}
+	}
+	return buckets
+}
+
+// Returns the id of the entity nearest to the passed in point and its distance (in sea miles),
+// or false if the index is empty.  Ties are broken by the lowest id.
+func (mi *MovingIndex) Nearest(p *Point) (id string, dist float64, ok bool) {
+	row, col := mi.cell(p.lat, p.lng)
+	visited := map[string]bool{}
+	best, bestDist := "", math.Inf(1)
+	closer := func(entry *movingEntry) {
+		d := p.GreatCircleDistance(&entry.point)
+		if d < bestDist || (d == bestDist && entry.id < best) {
+			best, bestDist = entry.id, d
+		}
+	}
+
+	// Widen the search ring by ring until an entity is found, then make sure no closer one lies in a bucket
+	// at the same distance, which may be further rings away from the point
+	for r := 0; best == ""; r++ {
+		if r > mi.latCells+mi.lngCells || len(visited) > mi.buckets() {
+			mi.visit(nil, closer)
+			break
+		}
+		ring := map[string]bool{}
+		for dRow := -r; dRow <= r; dRow++ {
+			if row+dRow < 0 || row+dRow >= mi.latCells {
+				continue
+			}
+			for dCol := -r; dCol <= r; dCol++ {
+				if dRow != -r && dRow != r && dCol != -r && dCol != r {
+					continue
+				}
+				if bucket := mi.bucket(row+dRow, col+dCol); !visited[bucket] {
+					visited[bucket] = true
+					ring[bucket] = true
+				}
+			}
+		}
+		mi.visit(ring, closer)
+	}
+	if best == "" {
+		return "", 0, false
+	}
+
+	mi.visit(mi.bucketsWithin(p, bestDist), closer)
+	return best, bestDist, true
+}
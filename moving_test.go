@@ -0,0 +1,235 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Ensures that entities are found where they were last moved to, and not after their removal.
+func TestMovingIndex(t *testing.T) {
+	mi := NewMovingIndex(5)
+	mi.Update("a", NewPoint(47.60, -122.30))
+	mi.Update("b", NewPoint(47.61, -122.30))
+	mi.Update("c", NewPoint(47.70, -122.30))
+	mi.Update("d", NewPoint(-33.8, 151.2))
+
+	if within := mi.Within(NewPoint(47.60, -122.30), 1); !reflect.DeepEqual(within, []string{"a", "b"}) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected [a b] within 1 sea mile, but got %v", within))
+	}
+
+	mi.Update("a", NewPoint(47.70, -122.31))
+	if within := mi.Within(NewPoint(47.60, -122.30), 1); !reflect.DeepEqual(within, []string{"b"}) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected [b] within 1 sea mile after moving a, but got %v", within))
+	}
+	if within := mi.Within(NewPoint(47.70, -122.30), 1); !reflect.DeepEqual(within, []string{"c", "a"}) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected [c a] within 1 sea mile of c, but got %v", within))
+	}
+
+	if id, dist, ok := mi.Nearest(NewPoint(-30, 150)); !ok || id != "d" || math.Abs(dist-NewPoint(-30, 150).GreatCircleDistance(NewPoint(-33.8, 151.2))) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected d to be nearest, but got %s at %v", id, dist))
+	}
+
+	mi.Remove("d")
+	mi.Remove("unknown")
+	if mi.Len() != 3 {
+		t.Errorf("Expected 3 entities after removing d, but got %d instead", mi.Len())
+	}
+	if id, _, _ := mi.Nearest(NewPoint(-30, 150)); id == "d" {
+		t.Error("Expected d to be gone after its removal")
+	}
+
+	for _, id := range []string{"a", "b", "c"} {
+		mi.Remove(id)
+	}
+	if _, _, ok := mi.Nearest(NewPoint(0, 0)); ok || mi.Len() != 0 {
+		t.Error("Expected an empty index")
+	}
+}
+
+// Ensures that searches reach across the antimeridian and around the poles.
+func TestMovingIndexEdges(t *testing.T) {
+	mi := NewMovingIndex(6)
+	mi.Update("east", NewPoint(0, 179.99))
+	mi.Update("west", NewPoint(0, -179.99))
+	mi.Update("pole", NewPoint(89.99, 45))
+	mi.Update("opposite", NewPoint(89.99, -135))
+
+	if within := mi.Within(NewPoint(0, 180), 1); !reflect.DeepEqual(within, []string{"east", "west"}) && !reflect.DeepEqual(within, []string{"west", "east"}) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected east and west within 1 sea mile of the antimeridian, but got %v", within))
+	}
+	if id, _, _ := mi.Nearest(NewPoint(0, -179.999)); id != "west" {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected west to be nearest, but got %s", id))
+	}
+	if within := mi.Within(NewPoint(89.99, 45), 1.5); !reflect.DeepEqual(within, []string{"pole", "opposite"}) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected both points across the north pole, but got %v", within))
+	}
+}
+
+// Ensures that entities which have not been updated for a while are reported as stale.
+func TestMovingIndexStale(t *testing.T) {
+	mi := NewMovingIndex(5)
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	mi.now = func() time.Time { return now }
+
+	mi.Update("a", NewPoint(1, 1))
+	mi.Update("b", NewPoint(2, 2))
+	now = now.Add(time.Minute)
+	mi.Update("c", NewPoint(3, 3))
+	mi.Update("a", NewPoint(1, 1.5))
+	now = now.Add(30 * time.Second)
+
+	if stale := mi.Stale(45 * time.Second); !reflect.DeepEqual(stale, []string{"b"}) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected b to be stale, but got %v", stale))
+	}
+	if stale := mi.Stale(10 * time.Second); !reflect.DeepEqual(stale, []string{"a", "b", "c"}) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected all entities to be stale, but got %v", stale))
+	}
+}
+
+// Returns the ids of the passed in points within the passed in radius of the passed in center, sorted by distance,
+// and the id of the nearest one, by checking all of them.
+func bruteForceMoving(points map[string]*Point, center *Point, radius float64) (within []string, nearest string) {
+	type match struct {
+		id   string
+		dist float64
+	}
+	matches := []match{}
+	bestDist := math.Inf(1)
+	for id, p := range points {
+		dist := center.GreatCircleDistance(p)
+		if dist <= radius {
+			matches = append(matches, match{id, dist})
+		}
+		if dist < bestDist || (dist == bestDist && id < nearest) {
+			nearest, bestDist = id, dist
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].dist != matches[j].dist {
+			return matches[i].dist < matches[j].dist
+		}
+		return matches[i].id < matches[j].id
+	})
+	within = []string{}
+	for _, m := range matches {
+		within = append(within, m.id)
+	}
+	return within, nearest
+}
+
+// Ensures that the queries match a brute force search, for dense and sparse areas.
+func TestMovingIndexMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	mi := NewMovingIndex(5)
+	points := map[string]*Point{}
+	for i := 0; i < 2000; i++ {
+		id := strconv.Itoa(i)
+		p := NewPoint(47.5+r.Float64()*0.3, -122.5+r.Float64()*0.4)
+		if i%100 == 0 {
+			p = NewPoint(r.Float64()*180-90, r.Float64()*360-180)
+		}
+		points[id] = p
+		mi.Update(id, p)
+	}
+
+	for i := 0; i < 100; i++ {
+		center := NewPoint(47.5+r.Float64()*0.3, -122.5+r.Float64()*0.4)
+		if i%10 == 0 {
+			center = NewPoint(r.Float64()*180-90, r.Float64()*360-180)
+		}
+		radius := r.Float64() * 5
+		within, nearest := bruteForceMoving(points, center, radius)
+
+		if actual := mi.Within(center, radius); !reflect.DeepEqual(actual, within) {
+			t.Fatalf("Expected %v within %v of %v, but got %v instead", within, radius, center, actual)
+		}
+		if actual, _, _ := mi.Nearest(center); actual != nearest {
+			t.Fatalf("Expected %s to be nearest to %v, but got %s instead", nearest, center, actual)
+		}
+	}
+}
+
+// Ensures that 10k entities can be updated at 1 Hz while querying at 100 Hz concurrently,
+// and that the index is consistent afterwards.  Run with -race to check for data races.
+func TestMovingIndexConcurrent(t *testing.T) {
+	const entities, updaters = 10000, 8
+	duration := time.Second
+	if testing.Short() {
+		duration = 200 * time.Millisecond
+	}
+
+	mi := NewMovingIndex(5)
+	var mu sync.Mutex
+	points := map[string]*Point{}
+	for i := 0; i < entities; i++ {
+		id := strconv.Itoa(i)
+		points[id] = NewPoint(47.5+float64(i%100)*0.003, -122.5+float64(i/100)*0.004)
+		mi.Update(id, points[id])
+	}
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for u := 0; u < updaters; u++ {
+		wg.Add(1)
+		go func(u int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(u)))
+			ticker := time.NewTicker(time.Second / (entities / updaters))
+			defer ticker.Stop()
+			for i := u; ; i += updaters {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+				}
+				id := strconv.Itoa(i % entities)
+				p := NewPoint(47.5+r.Float64()*0.3, -122.5+r.Float64()*0.4)
+				mu.Lock()
+				points[id] = p
+				mi.Update(id, p)
+				mu.Unlock()
+			}
+		}(u)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			mi.Within(NewPoint(47.65, -122.3), 2)
+			mi.Nearest(NewPoint(47.65, -122.3))
+			mi.Stale(time.Minute)
+		}
+	}()
+
+	time.Sleep(duration)
+	close(stop)
+	wg.Wait()
+
+	if mi.Len() != entities {
+		t.Errorf("Expected %d entities, but got %d instead", entities, mi.Len())
+	}
+	for _, center := range []*Point{NewPoint(47.65, -122.3), NewPoint(47.55, -122.45), NewPoint(47.79, -122.11)} {
+		within, nearest := bruteForceMoving(points, center, 1)
+		if actual := mi.Within(center, 1); !reflect.DeepEqual(actual, within) {
+			t.Errorf("Expected %d entities within 1 sea mile of %v, but got %d instead", len(within), center, len(actual))
+		}
+		if actual, _, _ := mi.Nearest(center); actual != nearest {
+			t.Errorf("Expected %s to be nearest to %v, but got %s instead", nearest, center, actual)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Returned, possibly wrapped, when a coordinate lies outside of its valid range.
+var ErrOutOfRange = errors.New("coordinate out of range")
+
+// Returned, wrapped in an error suggesting the swapped coordinates, by Parse when the latitude is out of range
+// but would be a valid longitude, while the longitude would be a valid latitude, e.g. for "-122.3, 47.6".
+// It wraps ErrOutOfRange.
+var ErrPossiblySwapped = fmt.Errorf("latitude and longitude possibly swapped: %w", ErrOutOfRange)
+
+// The error returned for a value whose coordinates are likely swapped.
+type swappedError struct {
+	value    string
+	lat, lng float64
+}
+
+func (e *swappedError) Error() string {
+	return fmt.Sprintf("Latitude %v of %q is out of range, did you mean %v, %v?", e.lat, e.value, e.lng, e.lat)
+}
+
+func (e *swappedError) Unwrap() error {
+	return ErrPossiblySwapped
+}
+
+// A Parser parses latitude/longitude strings in the same formats as Parse.
+// It scans the input by hand and keeps the parsed segments in scratch space
+// owned by the Parser, so repeated parsing does not allocate beyond the returned Point.
+// The zero value is ready to use.  A Parser is not safe for concurrent use.
+type Parser struct {
+	lat [5]string
+	lng [5]string
+	// The number of numeric components per coordinate of the last match
+	components int
+}
+
+// Parses a longitude/latitude string in a variety of formats and
+// returns a new Point populated with the parsed values.
+// The accepted formats are decimal degrees, decimal minutes and decimal seconds,
+// tried in that order, exactly as documented for Parse.
+// Values whose latitude is out of range while the coordinates would be valid the other way around
+// are rejected with an error wrapping ErrPossiblySwapped.
+func (ps *Parser) Parse(value string) (*Point, error) {
+	for n := 1; n <= 3; n++ {
+		if ps.match(value, n) {
+			p, err := ps.point()
+			if err == nil && possiblySwapped(p.lat, p.lng) {
+				return nil, &swappedError{value, p.lat, p.lng}
+			}
+			return p, err
+		}
+	}
+
+	// Longitudes beyond ±99 do not match the latitude of any format, so look for a plain pair of numbers
+	if lat, lng, ok := decimalPair(value); ok && possiblySwapped(lat, lng) {
+		return nil, &swappedError{value, lat, lng}
+	}
+	return nil, errors.New("Unable to parse value: " + value)
+}
+
+// Returns whether or not the passed in latitude is out of range, but the coordinates would be valid when swapped.
+func possiblySwapped(lat, lng float64) bool {
+	return math.Abs(lat) > 90 && math.Abs(lat) <= 180 && math.Abs(lng) <= 90
+}
+
+// Parses a pair of plain decimal numbers separated by a comma and/or whitespace,
+// optionally enclosed in parentheses, e.g. "(-122.3, 47.6)".
+func decimalPair(value string) (first, second float64, ok bool) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		value = value[1 : len(value)-1]
+	}
+	fields := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+	if len(fields) != 2 || strings.Count(value, ",") > 1 {
+		return 0, 0, false
+	}
+	first, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	second, err = strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return first, second, true
+}
+
+// Parses the passed in value like Parse, but swaps the coordinates of values rejected as possibly swapped,
+// e.g. "-122.3, 47.6" into the Point [47.6, -122.3].  Returns whether or not the coordinates were swapped.
+func ParseLenientSwap(value string) (*Point, bool, error) {
+	p, err := Parse(value)
+	var swapped *swappedError
+	if errors.As(err, &swapped) {
+		return NewPoint(swapped.lng, swapped.lat), true, nil
+	}
+	return p, false, err
+}
+
+// Same as Parse, but only accepts values in the passed in format.
+// Values rejected by Parse as possibly swapped are rejected alike.
+func (ps *Parser) parseFormat(value string, format Format) (*Point, error) {
+	if format < DecimalDegrees || format > DecimalSeconds {
+		return nil, errors.New("Invalid format: " + format.String())
+	}
+	if ps.match(value, int(format)+1) {
+		p, err := ps.point()
+		if err == nil && possiblySwapped(p.lat, p.lng) {
+			return nil, &swappedError{value, p.lat, p.lng}
+		}
+		return p, err
+	}
+
+	if lat, lng, ok := decimalPair(value); ok && format == DecimalDegrees && possiblySwapped(lat, lng) {
+		return nil, &swappedError{value, lat, lng}
+	}
+	return nil, errors.New("Unable to parse value as " + format.String() + ": " + value)
+}
+
+// Returns a new Point from the segments filled in by the last successful match.
+func (ps *Parser) point() (*Point, error) {
+	lat, err := calcValue(ps.lat[:ps.components+2])
+	if err != nil {
+		return nil, err
+	}
+	lng, err := calcValue(ps.lng[:ps.components+2])
+	if err != nil {
+		return nil, err
+	}
+	return NewPoint(lat, lng), nil
+}
+
+// Matches the entire value against the format with n numeric components
+// per coordinate (1 = degrees, 2 = minutes, 3 = seconds), filling the scratch segments
+// with the sign prefix, the components and the hemisphere suffix of each coordinate.
+func (ps *Parser) match(s string, n int) bool {
+	ps.components = n
+	lat := ps.lat[:n+2]
+	lng := ps.lng[:n+2]
+
+	i, ok := matchCoordinate(s, skipSpace(s, 0), n, "NS+-", 2, lat)
+	if !ok {
+		return false
+	}
+
+	// Hemisphere suffix of the latitude, followed by either
+	// whitespace or a comma separating it from the longitude.
+	j := skipSpace(s, i)
+	if j < len(s) && (s[j] == 'N' || s[j] == 'S') {
+		lat[n+1] = s[j : j+1]
+		j++
+		i = j
+		j = skipSpace(s, j)
+	} else {
+		lat[n+1] = ""
+	}
+
+	if j < len(s) && s[j] == ',' {
+		j = skipSpace(s, j+1)
+	} else if j == i {
+		return false
+	}
+
+	j, ok = matchCoordinate(s, j, n, "EW+-", 3, lng)
+	if !ok {
+		return false
+	}
+
+	j = skipSpace(s, j)
+	if j < len(s) && (s[j] == 'E' || s[j] == 'W') {
+		lng[n+1] = s[j : j+1]
+		j++
+	} else {
+		lng[n+1] = ""
+	}
+
+	return skipSpace(s, j) == len(s)
+}
+
+// Matches an optional sign prefix followed by n whitespace separated numeric components
+// starting at index i, storing the prefix and the components in seg.
+// The first component may have up to maxDigits integer digits, the others up to two,
+// and only the last component may have a fractional part.
+// Returns the index directly after the last component.
+func matchCoordinate(s string, i int, n int, prefixes string, maxDigits int, seg []string) (int, bool) {
+	seg[0] = ""
+	if i < len(s) && strings.IndexByte(prefixes, s[i]) >= 0 {
+		seg[0] = s[i : i+1]
+		i++
+	}
+	i = skipSpace(s, i)
+
+	for c := 0; c < n; c++ {
+		if c > 0 {
+			j := skipSpace(s, i)
+			if j == i {
+				return i, false
+			}
+			i = j
+		}
+
+		start := i
+		i = skipDigits(s, i)
+		digits := maxDigits
+		if c > 0 {
+			digits = 2
+		}
+		if i == start || i-start > digits {
+			return i, false
+		}
+
+		if c == n-1 && i < len(s) && s[i] == '.' {
+			i = skipDigits(s, i+1)
+		}
+		seg[c+1] = s[start:i]
+
+		// Optional unit marker of the component
+		switch {
+		case c == 0 && strings.HasPrefix(s[i:], "°"):
+			i += len("°")
+		case c == 1 && i < len(s) && s[i] == '\'':
+			i++
+		case c == 2 && i < len(s) && s[i] == '"':
+			i++
+		}
+	}
+
+	return i, true
+}
+
+// Returns the index of the first non whitespace character at or after i.
+func skipSpace(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\f' || s[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+// Returns the index of the first non digit character at or after i.
+func skipDigits(s string, i int) int {
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return i
+}
+
+// The unit keywords accepted by ParseVerbose, in lower case.
+var verboseKeywords = map[string]bool{
+	"deg": true, "degs": true, "degree": true, "degrees": true,
+	"min": true, "mins": true, "minute": true, "minutes": true,
+	"sec": true, "secs": true, "second": true, "seconds": true,
+}
+
+// Parses the passed in value in any of the formats accepted by ParseVerbose, and returns it in a canonical form
+// for storage, in decimal degrees with exactly 6 decimal places, e.g. "40.500000,-120.500000", along with the Point
+// of the canonical coordinates.  Longitudes are normalized into [-180, 180) and negative zeros dropped,
+// so all representations of the same location yield the same string.
+// Values outside of the valid range of latitudes and longitudes are rejected with an error wrapping ErrOutOfRange.
+func Canonicalize(value string) (canonical string, p *Point, err error) {
+	parsed, err := ParseVerbose(value)
+	if err != nil {
+		return "", nil, err
+	}
+	if math.Abs(parsed.lat) > 90 || math.Abs(parsed.lng) > 180 {
+		return "", nil, fmt.Errorf("%w: %v, %v in %q", ErrOutOfRange, parsed.lat, parsed.lng, value)
+	}
+
+	// Rounding first makes the string and the Point agree, adding 0 turns negative zeros positive
+	lat := math.Round(parsed.lat*1e6)/1e6 + 0
+	lng := math.Round(normalizeLongitude(parsed.lng)*1e6)/1e6 + 0
+	if lng >= 180 {
+		lng -= 360
+	}
+	return strconv.FormatFloat(lat, 'f', 6, 64) + "," + strconv.FormatFloat(lng, 'f', 6, 64), NewPoint(lat, lng), nil
+}
+
+// Parses a latitude/longitude string whose components are marked by unit keywords,
+// e.g. "40 deg 30 min N, 120 deg 30 min W", and returns a new Point populated with the parsed values.
+// The keywords deg, min and sec (and their long forms) as well as the ° symbol are accepted
+// case-insensitively, and so are the cardinal letters.  Once the keywords are stripped,
+// the value has to be in one of the formats accepted by Parse.
+func ParseVerbose(value string) (*Point, error) {
+	var b strings.Builder
+	b.Grow(len(value))
+
+	for i := 0; i < len(value); {
+		if strings.HasPrefix(value[i:], "°") {
+			b.WriteByte(' ')
+			i += len("°")
+			continue
+		}
+		if !isLetter(value[i]) {
+			b.WriteByte(value[i])
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(value) && isLetter(value[j]) {
+			j++
+		}
+		word := strings.ToLower(value[i:j])
+		switch {
+		case verboseKeywords[word]:
+			b.WriteByte(' ')
+		case word == "n" || word == "s" || word == "e" || word == "w":
+			b.WriteString(" " + strings.ToUpper(word) + " ")
+		default:
+			return nil, errors.New("Unable to parse value: " + value)
+		}
+		i = j
+	}
+
+	p, err := Parse(b.String())
+	var swapped *swappedError
+	if errors.As(err, &swapped) {
+		return nil, &swappedError{value, swapped.lat, swapped.lng}
+	}
+	if err != nil {
+		return nil, errors.New("Unable to parse value: " + value)
+	}
+	return p, nil
+}
+
+// Returns whether or not the passed in byte is an ASCII letter.
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,274 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"regexp"
+	"testing"
+)
+
+// The regular expression Parse used before the hand written Parser.
+// It is kept here to verify that both implementations agree.
+var legacyFormatRegex = regexp.MustCompile(
+	`^\s*(?P<ns>[NS+-]?)\s*(?P<lat_deg>\d{1,2}(?:\.\d*)?)°?\s*(?P<ns2>[NS]?)` +
+		`(?:\s+|\s*,\s*)` +
+		`(?P<ew>[EW+-]?)\s*(?P<lon_deg>\d{1,3}(?:\.\d*)?)°?\s*(?P<ew2>[EW]?)\s*$|` +
+		`^\s*(?P<ns>[NS+-]?)\s*(?P<lat_deg>\d{1,2})°?\s+(?P<lat_min>\d{1,2}(?:\.\d*)?)'?\s*(?P<ns2>[NS]?)` +
+		`(?:\s+|\s*,\s*)` +
+		`(?P<ew>[EW+-]?)\s*(?P<lon_deg>\d{1,3})°?\s+(?P<lon_min>\d{1,2}(?:\.\d*)?)'?\s*(?P<ew2>[EW]?)\s*$|` +
+		`^\s*(?P<ns>[NS+-]?)\s*(?P<lat_deg>\d{1,2})°?\s+(?P<lat_min>\d{1,2})'?\s+(?P<lat_sec>\d{1,2}(?:\.\d*)?)"?\s*(?P<ns2>[NS]?)` +
+		`(?:\s+|\s*,\s*)` +
+		`(?P<ew>[EW+-]?)\s*(?P<lon_deg>\d{1,3})°?\s+(?P<lon_min>\d{1,2})'?\s+(?P<lon_sec>\d{1,2}(?:\.\d*)?)"?\s*(?P<ew2>[EW]?)\s*$`)
+
+// The regular expression based implementation of Parse before the hand written Parser.
+func legacyParse(value string) (*Point, error) {
+	segments := legacyFormatRegex.FindStringSubmatch(value)
+	if len(segments) < 1 {
+		return nil, errors.New("Unable to parse value: " + value)
+	}
+	var ranges [][2]int
+	switch {
+	case segments[2] != "":
+		ranges = [][2]int{{1, 4}, {4, 7}}
+	case segments[8] != "":
+		ranges = [][2]int{{7, 11}, {11, 15}}
+	case segments[16] != "":
+		ranges = [][2]int{{15, 20}, {20, 25}}
+	default:
+		return nil, errors.New("Unable to parse value: " + value)
+	}
+	lat, err := calcValue(segments[ranges[0][0]:ranges[0][1]])
+	if err != nil {
+		return nil, err
+	}
+	lng, err := calcValue(segments[ranges[1][0]:ranges[1][1]])
+	if err != nil {
+		return nil, err
+	}
+	return NewPoint(lat, lng), nil
+}
+
+// Asserts that the Parser and the legacy regular expression agree on the passed in value.
+func assertParsersAgree(t *testing.T, value string) {
+	expected, expectedErr := legacyParse(value)
+	actual, actualErr := Parse(value)
+
+	// Unlike the legacy regular expression, Parse rejects coordinates which look swapped
+	if errors.Is(actualErr, ErrPossiblySwapped) {
+		return
+	}
+
+	if (expectedErr == nil) != (actualErr == nil) {
+		t.Fatalf("Expected Parse(%q) to return error %v, but got %v instead", value, expectedErr, actualErr)
+	}
+	if expectedErr != nil {
+		if expectedErr.Error() != actualErr.Error() {
+			t.Fatalf("Expected Parse(%q) to return error %v, but got %v instead", value, expectedErr, actualErr)
+		}
+		return
+	}
+	if expected.lat != actual.lat || expected.lng != actual.lng {
+		t.Fatalf("Expected Parse(%q) to return %v, but got %v instead", value, expected, actual)
+	}
+}
+
+// The alphabet random inputs are drawn from, biased towards the tokens of the grammar.
+var parseTokens = []string{
+	"0", "1", "2", "4", "5", "9", "12", "45", "120", "1234", ".", ".5", " ", "  ", "\t", ",", ", ",
+	"N", "S", "E", "W", "+", "-", "°", "'", `"`, "x", "\xc2",
+}
+
+// Ensures that the hand written Parser produces identical results to the
+// legacy regular expression for the existing test corpus and random token soup.
+func TestParserMatchesLegacyRegex(t *testing.T) {
+	corpus := []string{
+		"40.5, 120.5", "-40.5, -120.5", "-0.5, -0", "40 30.0, 120 30", "40° 30', 120 30",
+		"40 30.0 S, 120 30 W", "N 12 20 44.16, W 23 27 24.12", `45° 41' 59.1" N 69° 44' 01.4" W`,
+		"N 45 41.985, W 69 44.023", "S 45 41 59.100, E 69 41 1.399", "45.699750,-69.733722",
+		"", " ", "40", "40 30", "40 30 120 30", "40.5N120.5E", "40.5 N 120.5 E", "40.5N,120.5E",
+		"40.5 ,120.5", "40. , 120.", "100, 10", "10, 1000", "40 °, 120", "40 30 120 30 1 2",
+		"40.5 30, 120 30", "+40.5 -120.5", "N40.5 E120.5", "40.5 S 120.5 W\n",
+	}
+	for _, value := range corpus {
+		assertParsersAgree(t, value)
+	}
+
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 200000; i++ {
+		value := ""
+		for n := r.Intn(14); n > 0; n-- {
+			value += parseTokens[r.Intn(len(parseTokens))]
+		}
+		assertParsersAgree(t, value)
+	}
+}
+
+// Fuzzes the Parser against the legacy regular expression.
+func FuzzParserMatchesLegacyRegex(f *testing.F) {
+	f.Add("40.5, 120.5")
+	f.Add(`45° 41' 59.1" N 69° 44' 01.4" W`)
+	f.Add("40 30.0 S, 120 30 W")
+	f.Fuzz(func(t *testing.T, value string) {
+		assertParsersAgree(t, value)
+	})
+}
+
+// Ensures that a Parser only allocates the returned Point.
+func TestParserAllocations(t *testing.T) {
+	var ps Parser
+	allocs := testing.AllocsPerRun(100, func() {
+		ps.Parse(`45° 41' 59.1" N 69° 44' 01.4" W`)
+	})
+	if allocs > 1 {
+		t.Errorf("Expected the Parser to allocate at most the returned Point, but got %v allocations instead", allocs)
+	}
+}
+
+// Ensures that coordinates spelled out with unit keywords are parsed.
+func TestParseVerbose(t *testing.T) {
+	tests := []struct {
+		value    string
+		lat, lng float64
+	}{
+		{"40 deg 30 min N, 120 deg 30 min W", 40.5, -120.5},
+		{"40 DEG 30 MIN n 120 Deg 30 Min w", 40.5, -120.5},
+		{"40 degrees 30 minutes 36 seconds S, 120 degrees 15 minutes 0 seconds E", -40.51, 120.25},
+		{"40 deg 30 min 36 sec, 120 deg 30 min 36 sec", 40.51, 120.51},
+		{"N 40 deg 30 min, W 120 deg 30 min", 40.5, -120.5},
+		{"-40 deg 30 min, 120 deg 30 min", -40.5, 120.5},
+		{"40.25 deg, -120.75 deg", 40.25, -120.75},
+		{"40° 30 min 36 secs N, 120° 30 mins 36 sec W", 40.51, -120.51},
+	}
+
+	for _, test := range tests {
+		p, err := ParseVerbose(test.value)
+		if err != nil {
+			t.Error("Unable to parse", test.value, err)
+			continue
+		}
+		if math.Abs(p.Lat()-test.lat) > 1e-9 || math.Abs(p.Lng()-test.lng) > 1e-9 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Parsed %q as %v, expected [%v, %v]", test.value, p, test.lat, test.lng))
+		}
+	}
+
+	for _, value := range []string{"40 furlongs 30 min N, 120 deg W", "", "deg min, sec", "40 deg 30 min X, 120 deg 30 min W"} {
+		if _, err := ParseVerbose(value); err == nil {
+			t.Error("Expected an error parsing", value)
+		}
+	}
+}
+
+// Ensures that values with swapped coordinates are rejected with a suggestion, but ambiguous ones are not.
+func TestParsePossiblySwapped(t *testing.T) {
+	for _, value := range []string{"-122.3, 47.6", "(-122.3, 47.6)", "122.3 47.6", "95.5, 40", "95 30, 40 10", "-179.9,-89.9"} {
+		_, err := Parse(value)
+		if !errors.Is(err, ErrPossiblySwapped) || !errors.Is(err, ErrOutOfRange) {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %q to be rejected as possibly swapped, but got %v", value, err))
+		}
+	}
+
+	_, err := Parse("-122.3, 47.6")
+	if err == nil || err.Error() != `Latitude -122.3 of "-122.3, 47.6" is out of range, did you mean 47.6, -122.3?` {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the error to suggest the swapped coordinates, but got %v", err))
+	}
+
+	// Both values are valid latitudes, or neither is a valid latitude when swapped
+	for _, value := range []string{"47.6, -12.3", "-12.3, 47.6", "-122.3, 147.6", "190, 40", "1, 2, 3"} {
+		if _, err := Parse(value); errors.Is(err, ErrPossiblySwapped) {
+			t.Error("Expected no swap suggestion for", value)
+		}
+	}
+}
+
+// Ensures that ParseLenientSwap swaps the coordinates of values which look swapped and reports it.
+func TestParseLenientSwap(t *testing.T) {
+	tests := []struct {
+		value    string
+		lat, lng float64
+		swapped  bool
+	}{
+		{"-122.3, 47.6", 47.6, -122.3, true},
+		{"(-122.3, 47.6)", 47.6, -122.3, true},
+		{"47.6, -122.3", 47.6, -122.3, false},
+		{"47.6, -12.3", 47.6, -12.3, false},
+	}
+
+	for _, test := range tests {
+		p, swapped, err := ParseLenientSwap(test.value)
+		if err != nil {
+			t.Error("Unable to parse", test.value, err)
+			continue
+		}
+		if p.Lat() != test.lat || p.Lng() != test.lng || swapped != test.swapped {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Parsed %q as %v (swapped %v), expected [%v, %v] (swapped %v)", test.value, p, swapped, test.lat, test.lng, test.swapped))
+		}
+	}
+
+	if _, swapped, err := ParseLenientSwap("nonsense"); err == nil || swapped {
+		t.Error("Expected an error without a swap parsing nonsense")
+	}
+}
+
+// Ensures that all representations of the same location are canonicalized to the same string.
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		canonical string
+		values    []string
+	}{
+		{"40.500000,120.500000", []string{"40.5, 120.5", "40 30.0, 120 30", "40° 30', 120 30", "40 deg 30 min, 120 deg 30 min", "N 40.5 E 120.5", "40.5000001,120.4999999"}},
+		{"-40.500000,-120.500000", []string{"-40.5, -120.5", "40 30.0 S, 120 30 W", "40 DEG 30 MIN S 120 Deg 30 Min W", "S 40 30 0, W 120 30 0"}},
+		{"-0.500000,0.000000", []string{"-0.5, -0", "0 30 S, 0 0 W", "-0.5, -0.0000001"}},
+		{"12.345600,-23.456700", []string{"N 12 20 44.16, W 23 27 24.12", "12.3456, -23.4567"}},
+		{"45.699750,-69.733722", []string{`45° 41' 59.1" N 69° 44' 01.4" W`, "45.69975,-69.733722"}},
+		{"-10.000000,-180.000000", []string{"-10, 180", "-10, -180"}},
+	}
+
+	for _, test := range tests {
+		for _, value := range test.values {
+			canonical, p, err := Canonicalize(value)
+			if err != nil {
+				t.Error("Unable to canonicalize", value, err)
+				continue
+			}
+			if canonical != test.canonical {
+				t.Error("Unnacceptable result.", fmt.Sprintf("Canonicalized %q as %q, expected %q", value, canonical, test.canonical))
+			}
+			if s, _ := p.Format(DecimalDegrees); s != canonical {
+				t.Error("Unnacceptable result.", fmt.Sprintf("Expected the Point of %q to format as %q, but got %q", value, canonical, s))
+			}
+		}
+	}
+
+	for _, value := range []string{"95.5, 140", "-122.3, 47.6", "40, 190"} {
+		if _, _, err := Canonicalize(value); !errors.Is(err, ErrOutOfRange) {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %q to be rejected as out of range, but got %v", value, err))
+		}
+	}
+	if _, _, err := Canonicalize("nonsense"); err == nil {
+		t.Error("Expected an error canonicalizing nonsense")
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Parse(`45° 41' 59.1" N 69° 44' 01.4" W`)
+	}
+}
+
+func BenchmarkParser(b *testing.B) {
+	var ps Parser
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ps.Parse(`45° 41' 59.1" N 69° 44' 01.4" W`)
+	}
+}
+
+func BenchmarkLegacyParse(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		legacyParse(`45° 41' 59.1" N 69° 44' 01.4" W`)
+	}
+}
@@ -8,8 +8,8 @@ import (
 	"fmt"
 	"log"
 	"math"
-	"regexp"
 	"strconv"
+	"strings"
 )
 
 // Represents a Physical Point in geographic notation [lat, lng].
@@ -21,38 +21,69 @@ type Point struct {
 const (
 	// According to Wikipedia, the Earth's radius is about 6,371km
 	EARTHRADIUS = 3440.065334773 // sea miles
+
+	// A sea mile is defined as exactly 1852 meters
+	metersPerSeaMile = 1852.0
+
+	// The semi-major axis (in meters) and flattening of the WGS-84 ellipsoid, as used by VincentyDistance
+	WGS84SemiMajorAxis = 6378137.0
+	WGS84Flattening    = 1 / 298.257223563
 )
 
 type Format int
 
 const (
 	// Decimal degrees format, e.g. 45.699750,-69.733722
-	DecimalDegrees = iota
+	DecimalDegrees Format = iota
 	// Decimal minutes format, e.g. N 45 41.985, W 69 44.023
 	DecimalMinutes
-	// Decimal seconds format, e.g. N 45 41 59.100, W 69 41 1.399
+	// Decimal seconds format, e.g. N 45 41 59.100, W 69 44 1.399
 	DecimalSeconds
 )
 
-var formatRegex *regexp.Regexp
-
-func init() {
-	// formatRegex matches three different text formats for latitude/longitude vales
-	formatRegex =
-		regexp.MustCompile(
-			// Decimal degrees, e.g. 45.699958,-69.733729 or N 45.699958 W 69.733729
-			// or 45.699958 N 69.733729 W
-			`^\s*(?P<ns>[NS+-]?)\s*(?P<lat_deg>\d{1,2}(?:\.\d*)?)°?\s*(?P<ns2>[NS]?)` +
-				`(?:\s+|\s*,\s*)` +
-				`(?P<ew>[EW+-]?)\s*(?P<lon_deg>\d{1,3}(?:\.\d*)?)°?\s*(?P<ew2>[EW]?)\s*$|` +
-			// Decimal minutes, e.g. 45 41.997, -69 44.024 or N 45 41.997 W 69 44.024
-				`^\s*(?P<ns>[NS+-]?)\s*(?P<lat_deg>\d{1,2})°?\s+(?P<lat_min>\d{1,2}(?:\.\d*)?)'?\s*(?P<ns2>[NS]?)` +
-				`(?:\s+|\s*,\s*)` +
-				`(?P<ew>[EW+-]?)\s*(?P<lon_deg>\d{1,3})°?\s+(?P<lon_min>\d{1,2}(?:\.\d*)?)'?\s*(?P<ew2>[EW]?)\s*$|` +
-			// Decimal seconds, e.g. 45 41 59.85, -69 44 01.42 or N 45 41 59.85, W 69 44 01.42
-				`^\s*(?P<ns>[NS+-]?)\s*(?P<lat_deg>\d{1,2})°?\s+(?P<lat_min>\d{1,2})'?\s+(?P<lat_sec>\d{1,2}(?:\.\d*)?)"?\s*(?P<ns2>[NS]?)` +
-				`(?:\s+|\s*,\s*)` +
-				`(?P<ew>[EW+-]?)\s*(?P<lon_deg>\d{1,3})°?\s+(?P<lon_min>\d{1,2})'?\s+(?P<lon_sec>\d{1,2}(?:\.\d*)?)"?\s*(?P<ew2>[EW]?)\s*$`)
+// The names of the formats, as returned by Format.String.
+var formatNames = []string{"decimal_degrees", "decimal_minutes", "decimal_seconds"}
+
+// Returns the snake_case name of the format, e.g. "decimal_degrees".
+func (f Format) String() string {
+	if f < 0 || int(f) >= len(formatNames) {
+		return "Format(" + strconv.Itoa(int(f)) + ")"
+	}
+	return formatNames[f]
+}
+
+// Returns the Format with the passed in name.
+// Both the snake_case (e.g. "decimal_degrees") and the CamelCase (e.g. "DecimalDegrees")
+// spellings of the names are accepted.
+func ParseFormatName(s string) (Format, error) {
+	name := strings.ToLower(strings.Replace(s, "_", "", -1))
+	for i, formatName := range formatNames {
+		if name == strings.Replace(formatName, "_", "", -1) {
+			return Format(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("Unknown format %q, valid formats are %s", s, strings.Join(formatNames, ", "))
+}
+
+// Renders the format as its name.
+// Implements the encoding.TextMarshaler Interface.
+func (f Format) MarshalText() ([]byte, error) {
+	if f < 0 || int(f) >= len(formatNames) {
+		return nil, errors.New("Invalid format: " + f.String())
+	}
+	return []byte(f.String()), nil
+}
+
+// Decodes the format from its name, as accepted by ParseFormatName.
+// Implements the encoding.TextUnmarshaler Interface.
+func (f *Format) UnmarshalText(text []byte) error {
+	format, err := ParseFormatName(string(text))
+	if err != nil {
+		return err
+	}
+	*f = format
+	return nil
 }
 
 // Returns a new Point populated by the passed in latitude (lat) and longitude (lng) values.
@@ -62,45 +93,14 @@ func NewPoint(lat float64, lng float64) *Point {
 
 // Parses a longitude/latitude string in a variety of formats and
 // returns a new Point populated with the parsed values.
+// Supported are decimal degrees (e.g. 45.699958,-69.733729 or N 45.699958 W 69.733729),
+// decimal minutes (e.g. 45 41.997, -69 44.024 or N 45 41.997 W 69 44.024)
+// and decimal seconds (e.g. 45 41 59.85, -69 44 01.42 or N 45 41 59.85, W 69 44 01.42).
+// Values with an out of range latitude which would be valid with the coordinates swapped,
+// e.g. -122.3, 47.6, are rejected with an error wrapping ErrPossiblySwapped.
 func Parse(value string) (*Point, error) {
-	segments := formatRegex.FindStringSubmatch(value)
-	if len(segments) < 1 {
-		return nil, errors.New("Unable to parse value: " + value)
-	}
-	switch {
-	case segments[2] != "":
-		lat, err := calcValue(segments[1:4])
-		if err != nil {
-			return nil, err
-		}
-		lng, err := calcValue(segments[4:7])
-		if err != nil {
-			return nil, err
-		}
-		return NewPoint(lat, lng), err
-	case segments[8] != "":
-		lat, err := calcValue(segments[7:11])
-		if err != nil {
-			return nil, err
-		}
-		lng, err := calcValue(segments[11:15])
-		if err != nil {
-			return nil, err
-		}
-		return NewPoint(lat, lng), err
-	case segments[16] != "":
-		lat, err := calcValue(segments[15:20])
-		if err != nil {
-			return nil, err
-		}
-		lng, err := calcValue(segments[20:25])
-		if err != nil {
-			return nil, err
-		}
-		return NewPoint(lat, lng), err
-	default:
-		return nil, errors.New("Unable to parse value: " + value)
-	}
+	var ps Parser
+	return ps.Parse(value)
 }
 
 /*
@@ -141,9 +141,27 @@ func calcValue(segments []string) (value float64, err error) {
 
 // Formats a Point into one of several common string formats
 func (p *Point) Format(format Format) (string, error) {
+	var buf [64]byte
+	b, err := p.AppendFormat(buf[:0], format)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Appends the current Point formatted like Format to the passed in buffer and returns the extended buffer.
+func (p *Point) AppendFormat(dst []byte, format Format) ([]byte, error) {
+	return p.appendFormat(dst, format, defaultPrecision)
+}
+
+// Appends the current Point formatted like Format, with the decimal places of the passed in PrecisionPolicy,
+// to the passed in buffer and returns the extended buffer.
+func (p *Point) appendFormat(dst []byte, format Format, precision PrecisionPolicy) ([]byte, error) {
 	switch format {
 	case DecimalDegrees:
-		return fmt.Sprintf("%f,%f", p.lat, p.lng), nil
+		dst = strconv.AppendFloat(dst, p.lat, 'f', precision.FormatDecimals, 64)
+		dst = append(dst, ',')
+		return strconv.AppendFloat(dst, p.lng, 'f', precision.FormatDecimals, 64), nil
 	case DecimalMinutes:
 		ns := "N"
 		if p.lat < 0 {
@@ -159,7 +177,9 @@ func (p *Point) Format(format Format) (string, error) {
 		lngi, lngr := math.Modf(math.Abs(p.lng))
 		lngd := int(lngi)
 		lngm := lngr * 60.0
-		return fmt.Sprintf("%s %d %.3f, %s %d %.3f", ns, latd, latm, ew, lngd, lngm), nil
+		dst = appendAngle(dst, ns, latd, -1, latm)
+		dst = append(dst, ", "...)
+		return appendAngle(dst, ew, lngd, -1, lngm), nil
 	case DecimalSeconds:
 		ns := "N"
 		if p.lat < 0 {
@@ -179,12 +199,28 @@ func (p *Point) Format(format Format) (string, error) {
 		lngd := int(lngi)
 		lngmf := lngf * 60.0
 		lngi, lngf = math.Modf(lngmf)
-		lngm := int(lati)
+		lngm := int(lngi)
 		lngs := lngf * 60.0
-		return fmt.Sprintf("%s %d %d %.3f, %s %d %d %.3f", ns, latd, latm, lats, ew, lngd, lngm, lngs), nil
+		dst = appendAngle(dst, ns, latd, latm, lats)
+		dst = append(dst, ", "...)
+		return appendAngle(dst, ew, lngd, lngm, lngs), nil
 	default:
-		return "", errors.New("Invalid format: " + string(format))
+		return dst, errors.New("Invalid format: " + format.String())
+	}
+}
+
+// Appends an angle as its hemisphere, whole degrees, whole minutes unless negative,
+// and the remainder with three decimals, separated by spaces.
+func appendAngle(dst []byte, hemisphere string, degrees, minutes int, remainder float64) []byte {
+	dst = append(dst, hemisphere...)
+	dst = append(dst, ' ')
+	dst = strconv.AppendInt(dst, int64(degrees), 10)
+	dst = append(dst, ' ')
+	if minutes >= 0 {
+		dst = strconv.AppendInt(dst, int64(minutes), 10)
+		dst = append(dst, ' ')
 	}
+	return strconv.AppendFloat(dst, remainder, 'f', 3, 64)
 }
 
 // Returns Point p's latitude.
@@ -233,6 +269,13 @@ func (p *Point) PointAtDistanceAndBearing(dist float64, bearing float64) *Point
 	return &Point{lat: lat2, lng: lng2}
 }
 
+// Returns the Point reached from 'this' point like PointAtDistanceAndBearing,
+// with the distance given in the passed in unit.
+// Panics if the unit is not one of the Unit constants.
+func (p *Point) PointAtDistanceAndBearingIn(dist float64, bearing float64, unit Unit) *Point {
+	return p.PointAtDistanceAndBearing(float64(NewDistance(dist, unit)), bearing)
+}
+
 // Calculates the Haversine distance between two points in sea miles.
 // Original Implementation from: http://www.movable-type.co.uk/scripts/latlong.html
 func (p *Point) GreatCircleDistance(p2 *Point) float64 {
@@ -245,13 +288,41 @@ func (p *Point) GreatCircleDistance(p2 *Point) float64 {
 	a1 := math.Sin(dLat/2) * math.Sin(dLat/2)
 	a2 := math.Sin(dLon/2) * math.Sin(dLon/2) * math.Cos(lat1) * math.Cos(lat2)
 
-	a := a1 + a2
+	// Rounding can push a past 1 for antipodal points
+	a := math.Min(a1+a2, 1)
 
 	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
 
 	return EARTHRADIUS * c
 }
 
+// Calculates the Haversine distance between two points in the passed in unit.
+// Identical points are exactly 0 apart in every unit.
+// Panics if the unit is not one of the Unit constants.
+func (p *Point) GreatCircleDistanceIn(p2 *Point, unit Unit) float64 {
+	return Distance(p.GreatCircleDistance(p2)).In(unit)
+}
+
+// Returns the Haversine distances (in sea miles) from 'this' point to each of the passed in targets.
+func (p *Point) GreatCircleDistances(targets []*Point) []float64 {
+	out := make([]float64, len(targets))
+	p.GreatCircleDistancesInto(targets, out)
+	return out
+}
+
+// Writes the Haversine distances (in sea miles) from 'this' point to each of the passed in targets
+// into the first len(targets) elements of the passed in slice, so hot loops can reuse it without allocating.
+// Returns an error if the slice is shorter than the targets.
+func (p *Point) GreatCircleDistancesInto(targets []*Point, out []float64) error {
+	if len(out) < len(targets) {
+		return fmt.Errorf("Output of length %d is too short for %d targets", len(out), len(targets))
+	}
+	for i, target := range targets {
+		out[i] = p.GreatCircleDistance(target)
+	}
+	return nil
+}
+
 // returns cross track error in sea miles
 func (p *Point) CrossTrackError(start *Point, end *Point) float64 {
 
@@ -286,6 +357,50 @@ func (p *Point) AlongTrackDistance(start *Point, end *Point) float64 {
 	return dat
 }
 
+// Calculates the distance in sea miles from the current Point to the great circle arc from start to end.
+// Unlike CrossTrackError, which measures to the whole great circle, this is the (unsigned) cross track distance
+// only if the current Point lies abreast of the arc, and the distance to the nearest end of the arc otherwise.
+func (p *Point) DistanceToArc(start *Point, end *Point) float64 {
+	return distanceToSegment(start, end, p)
+}
+
+// Calculates the distance in sea miles from the current Point to the nearest point on the equator,
+// which lies directly north or south of it.
+func (p *Point) DistanceToEquator() float64 {
+	return math.Abs(p.lat) * math.Pi / 180.0 * EARTHRADIUS
+}
+
+// Calculates the great circle distance in sea miles from the current Point
+// to the nearest point on the prime meridian (longitude 0, from pole to pole).
+// For points more than 90 degrees east or west of it, the nearest point is a pole.
+func (p *Point) DistanceToPrimeMeridian() float64 {
+	lat := p.lat * math.Pi / 180.0
+	lng := p.lng * math.Pi / 180.0
+
+	if math.Cos(lng) < 0 {
+		return (math.Pi/2 - math.Abs(lat)) * EARTHRADIUS
+	}
+
+	return math.Asin(math.Abs(math.Cos(lat)*math.Sin(lng))) * EARTHRADIUS
+}
+
+// Returns the difference lng2 - lng1 (in degrees) the short way around the globe,
+// normalized into [-180, 180), e.g. 0.2 from 179.9 to -179.9.
+func ShortestLongitudeDelta(lng1, lng2 float64) float64 {
+	delta := math.Mod(lng2-lng1, 360.0)
+	if delta < -180.0 {
+		delta += 360.0
+	} else if delta >= 180.0 {
+		delta -= 360.0
+	}
+	return delta
+}
+
+// Returns the passed in longitude (in degrees) normalized into [-180, 180).
+func normalizeLongitude(lng float64) float64 {
+	return ShortestLongitudeDelta(0, lng)
+}
+
 // Calculates the initial bearing (sometimes referred to as forward azimuth)
 // Original Implementation from: http://www.movable-type.co.uk/scripts/latlong.html
 func (p *Point) BearingTo(p2 *Point) float64 {
@@ -307,7 +422,49 @@ func (p *Point) BearingTo(p2 *Point) float64 {
 	return brng
 }
 
+// Returns the bearing towards the nearest pole along the meridian of 'this' point, and the name of that pole:
+// 0 and "N" in the northern hemisphere, 180 and "S" in the southern hemisphere.
+// Both poles are equally near to points on the equator, which are treated like those of the southern hemisphere.
+func (p *Point) BearingToNearestPole() (bearing float64, pole string) {
+	if p.lat > 0 {
+		return 0, "N"
+	}
+	// Includes the equator, where lat is 0
+	return 180, "S"
+}
+
+// Returns the direction (in degrees, within [0, 360)) of the sum of the unit vectors along the initial bearings
+// from 'this' point to each of the passed in targets, weighted by the inverse of their distances,
+// so nearer targets pull harder.  Targets coincident with 'this' point have no bearing and are ignored.
+// Returns NaN if no target remains or their pulls cancel out.
+func (p *Point) ResultantBearing(targets []*Point) float64 {
+	east, north := 0.0, 0.0
+	for _, target := range targets {
+		dist := p.GreatCircleDistance(target)
+		if dist < 1e-9 {
+			continue
+		}
+		bearing := p.BearingTo(target) * math.Pi / 180.0
+		east += math.Sin(bearing) / dist
+		north += math.Cos(bearing) / dist
+	}
+
+	if math.Hypot(east, north) < 1e-12 {
+		return math.NaN()
+	}
+	brng := math.Atan2(east, north) * 180.0 / math.Pi
+	if brng < 0 {
+		brng += 360
+	}
+	// Rounding may turn tiny negative angles into 360
+	if brng >= 360 {
+		brng -= 360
+	}
+	return brng
+}
+
 // Calculates the midpoint between 'this' point and the supplied point.
+// The longitude of the midpoint is normalized into [-180, 180).
 // Original implementation from http://www.movable-type.co.uk/scripts/latlong.html
 func (p *Point) MidpointTo(p2 *Point) *Point {
 	lat1 := p.lat * math.Pi / 180.0
@@ -326,11 +483,177 @@ func (p *Point) MidpointTo(p2 *Point) *Point {
 	lon3Rad := lon1 + math.Atan2(by, math.Cos(lat1)+bx)
 
 	lat3 := lat3Rad * 180.0 / math.Pi
-	lon3 := lon3Rad * 180.0 / math.Pi
+	lon3 := normalizeLongitude(lon3Rad * 180.0 / math.Pi)
 
 	return NewPoint(lat3, lon3)
 }
 
+// Calculates the weighted midpoint of the passed in points, by averaging their
+// 3D cartesian positions with the passed in weights and projecting the result back onto the sphere.
+// With equal weights this is the same as p1.MidpointTo(p2), and it is correct across the antimeridian.
+// The result is undefined if the weighted positions cancel each other out,
+// e.g. for equally weighted antipodal points.
+func WeightedMidpoint(p1 *Point, w1 float64, p2 *Point, w2 float64) *Point {
+	x1, y1, z1 := p1.toVector()
+	x2, y2, z2 := p2.toVector()
+	return pointFromVector(w1*x1+w2*x2, w1*y1+w2*y2, w1*z1+w2*z2)
+}
+
+// Calculates the Point at the passed in fraction (0 = this Point, 1 = the supplied Point)
+// along the great circle between 'this' point and the supplied point.
+// The result is undefined for antipodal points, as there is no unique great circle between them.
+// Original implementation from http://www.movable-type.co.uk/scripts/latlong.html
+func (p *Point) IntermediatePointTo(p2 *Point, fraction float64) *Point {
+	delta := p.GreatCircleDistance(p2) / EARTHRADIUS
+	if delta == 0 {
+		return NewPoint(p.lat, p.lng)
+	}
+
+	a := math.Sin((1-fraction)*delta) / math.Sin(delta)
+	b := math.Sin(fraction*delta) / math.Sin(delta)
+
+	x1, y1, z1 := p.toVector()
+	x2, y2, z2 := p2.toVector()
+
+	return pointFromVector(a*x1+b*x2, a*y1+b*y2, a*z1+b*z2)
+}
+
+// Calculates the Point at the passed in fraction along the great circle between each start and the end of the same index,
+// using IntermediatePointTo.  Returns an error if the number of starts and ends differ.
+func SlerpBatch(starts, ends []*Point, fraction float64) ([]*Point, error) {
+	if len(starts) != len(ends) {
+		return nil, fmt.Errorf("Mismatched number of starts (%d) and ends (%d)", len(starts), len(ends))
+	}
+	points := make([]*Point, len(starts))
+	for i, start := range starts {
+		points[i] = start.IntermediatePointTo(ends[i], fraction)
+	}
+	return points, nil
+}
+
+// Returns the points of the great circle arc from 'this' point to the supplied point,
+// spaced at most resolutionDeg degrees of arc apart, split into several segments
+// wherever the arc crosses the antimeridian so that it can be drawn on a flat map.
+// Split segments end and start at the crossing, at longitude 180 and -180 respectively.
+func (p *Point) GreatCircleSegments(p2 *Point, resolutionDeg float64) [][]*Point {
+	points := p.greatCirclePoints(p2, resolutionDeg)
+
+	segments := [][]*Point{}
+	segment := []*Point{points[0]}
+	for _, next := range points[1:] {
+		prev := segment[len(segment)-1]
+		if math.Abs(next.lng-prev.lng) > 180 {
+			lng := 180.0
+			if prev.lng < 0 {
+				lng = -180.0
+			}
+			lat := p.latitudeAtLongitude(p2, lng)
+			segment = append(segment, NewPoint(lat, lng))
+			segments = append(segments, segment)
+			segment = []*Point{NewPoint(lat, -lng)}
+		}
+
+		segment = append(segment, next)
+	}
+
+	return append(segments, segment)
+}
+
+// Returns the points of the great circle arc from 'this' point to the supplied point,
+// including both of them, spaced at most resolutionDeg degrees of arc apart.
+func (p *Point) greatCirclePoints(p2 *Point, resolutionDeg float64) []*Point {
+	arc := p.GreatCircleDistance(p2) / EARTHRADIUS * 180.0 / math.Pi
+
+	steps := 1
+	if resolutionDeg > 0 {
+		steps = int(math.Ceil(arc / resolutionDeg))
+		if steps < 1 {
+			steps = 1
+		}
+	}
+
+	points := []*Point{NewPoint(p.lat, p.lng)}
+	for i := 1; i < steps; i++ {
+		points = append(points, p.IntermediatePointTo(p2, float64(i)/float64(steps)))
+	}
+
+	return append(points, NewPoint(p2.lat, p2.lng))
+}
+
+// The spacing in degrees of arc at which RouteWithinLatitudeBand samples the great circle.
+const latitudeBandResolution = 0.5
+
+// Returns the parts of the great circle arc from 'this' point to the supplied point
+// that stay within the passed in band of latitudes (in degrees), sampled every half degree of arc.
+// Parts are split where the arc leaves the band and start and end exactly on its boundary.
+// Excursions out of the band shorter than the sampling resolution are not detected.
+func (p *Point) RouteWithinLatitudeBand(p2 *Point, minLat, maxLat float64) [][]*Point {
+	inside := func(q *Point) bool {
+		return q.lat >= minLat && q.lat <= maxLat
+	}
+
+	// Finds the crossing of the band boundary between the passed in fractions
+	// of the arc, of which the first is inside and the second outside of the band.
+	crossing := func(in, out float64) *Point {
+		for i := 0; i < 64; i++ {
+			mid := (in + out) / 2
+			if inside(p.IntermediatePointTo(p2, mid)) {
+				in = mid
+			} else {
+				out = mid
+			}
+		}
+		q := p.IntermediatePointTo(p2, in)
+		if math.Abs(q.lat-maxLat) < math.Abs(q.lat-minLat) {
+			return NewPoint(maxLat, q.lng)
+		}
+		return NewPoint(minLat, q.lng)
+	}
+
+	points := p.greatCirclePoints(p2, latitudeBandResolution)
+	fraction := func(i int) float64 {
+		return float64(i) / float64(len(points)-1)
+	}
+
+	parts := [][]*Point{}
+	var part []*Point
+	for i, q := range points {
+		in := inside(q)
+		switch {
+		case in && part == nil && i > 0:
+			part = []*Point{crossing(fraction(i), fraction(i-1)), q}
+		case in && part == nil:
+			part = []*Point{q}
+		case in:
+			part = append(part, q)
+		case part != nil:
+			parts = append(parts, append(part, crossing(fraction(i-1), fraction(i))))
+			part = nil
+		}
+	}
+	if part != nil {
+		parts = append(parts, part)
+	}
+
+	return parts
+}
+
+// Calculates the latitude at which the great circle through 'this' point
+// and the supplied point crosses the passed in longitude.
+// Original implementation from http://www.edwilliams.org/avform.htm#Int
+func (p *Point) latitudeAtLongitude(p2 *Point, lng float64) float64 {
+	lat1 := p.lat * math.Pi / 180.0
+	lat2 := p2.lat * math.Pi / 180.0
+	lng1 := p.lng * math.Pi / 180.0
+	lng2 := p2.lng * math.Pi / 180.0
+	lng = lng * math.Pi / 180.0
+
+	num := math.Sin(lat1)*math.Cos(lat2)*math.Sin(lng-lng2) - math.Sin(lat2)*math.Cos(lat1)*math.Sin(lng-lng1)
+	den := math.Cos(lat1) * math.Cos(lat2) * math.Sin(lng1-lng2)
+
+	return math.Atan(num/den) * 180.0 / math.Pi
+}
+
 // Renders the current point to a byte slice.
 // Implements the encoding.BinaryMarshaler Interface.
 func (p *Point) MarshalBinary() ([]byte, error) {
@@ -367,27 +690,214 @@ func (p *Point) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// The keys used for the latitude and longitude of a Point in JSON.
+type FieldNames struct {
+	Lat string
+	Lng string
+}
+
+// The keys MarshalJSON and UnmarshalJSON use for the latitude and longitude.
+// Change them to match the expectations of other systems, e.g. {"lat", "lon"} for Elasticsearch.
+var JSONFieldNames = FieldNames{Lat: "lat", Lng: "lng"}
+
+// The number of decimal places coordinates in decimal degrees are rendered with, in each context.
+// ShortestPrecision renders as many as needed to represent them exactly.
+// The methods of Point use DefaultPrecision, the methods of a PrecisionPolicy render Points with it instead.
+type PrecisionPolicy struct {
+	// Used by Format and AppendFormat for DecimalDegrees
+	FormatDecimals int
+	// Used by AppendJSON
+	JSONDecimals int
+}
+
+// Renders coordinates with as many decimal places as needed to represent them exactly.
+const ShortestPrecision = -1
+
+// The PrecisionPolicy returned by DefaultPrecision.
+var defaultPrecision = PrecisionPolicy{FormatDecimals: 6, JSONDecimals: ShortestPrecision}
+
+// Returns the PrecisionPolicy used by the methods of Point: 6 decimal places for Format and the shortest exact
+// representation for JSON.
+func DefaultPrecision() PrecisionPolicy {
+	return defaultPrecision
+}
+
+// Returns a PrecisionPolicy using the passed in number of decimal places in every context.
+func UniformPrecision(decimals int) PrecisionPolicy {
+	return PrecisionPolicy{FormatDecimals: decimals, JSONDecimals: decimals}
+}
+
+// Formats the passed in Point like its Format method, with the decimal places of the current PrecisionPolicy.
+func (pp PrecisionPolicy) Format(p *Point, format Format) (string, error) {
+	var buf [64]byte
+	b, err := pp.AppendFormat(buf[:0], p, format)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Appends the passed in Point formatted like its Format method, with the decimal places of the current
+// PrecisionPolicy, to the passed in buffer and returns the extended buffer.
+func (pp PrecisionPolicy) AppendFormat(dst []byte, p *Point, format Format) ([]byte, error) {
+	return p.appendFormat(dst, format, pp)
+}
+
+// Appends the passed in Point rendered to JSON like its MarshalJSON method, with the decimal places of the
+// current PrecisionPolicy, to the passed in buffer and returns the extended buffer.
+func (pp PrecisionPolicy) AppendJSON(dst []byte, p *Point) ([]byte, error) {
+	start := len(dst)
+	dst, err := appendJSONKey(dst, JSONFieldNames.Lat)
+	if err != nil {
+		return dst[:start], err
+	}
+	split := len(dst)
+	if dst, err = appendJSONKey(dst, JSONFieldNames.Lng); err != nil {
+		return dst[:start], err
+	}
+	keys := len(dst)
+	dst = p.appendJSON(dst, dst[start:split], dst[split:keys], pp)
+	return append(dst[:start], dst[keys:]...), nil
+}
+
 // Renders the current Point to valid JSON.
 // Implements the json.Marshaller Interface.
+// The keys are taken from JSONFieldNames.
 func (p *Point) MarshalJSON() ([]byte, error) {
-	res := fmt.Sprintf(`{"lat":%v, "lng":%v}`, p.lat, p.lng)
-	return []byte(res), nil
+	return p.FormatJSON(JSONFieldNames.Lat, JSONFieldNames.Lng)
+}
+
+// Renders the current Point to valid JSON using the passed in keys
+// for the latitude and longitude.
+func (p *Point) FormatJSON(latKey, lngKey string) ([]byte, error) {
+	lat, err := json.Marshal(latKey)
+	if err != nil {
+		return nil, err
+	}
+	lng, err := json.Marshal(lngKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.appendJSON(nil, lat, lng, defaultPrecision), nil
+}
+
+// Appends the current Point as a JSON object with the passed in encoded keys to the passed in buffer,
+// with the JSON decimal places of the passed in PrecisionPolicy.
+func (p *Point) appendJSON(dst []byte, latKey, lngKey []byte, precision PrecisionPolicy) []byte {
+	dst = append(dst, '{')
+	dst = append(dst, latKey...)
+	dst = append(dst, ':')
+	dst = appendJSONNumber(dst, p.lat, precision.JSONDecimals)
+	dst = append(dst, ", "...)
+	dst = append(dst, lngKey...)
+	dst = append(dst, ':')
+	dst = appendJSONNumber(dst, p.lng, precision.JSONDecimals)
+	return append(dst, '}')
+}
+
+// Appends the passed in coordinate with the passed in number of decimal places, or ShortestPrecision.
+func appendJSONNumber(dst []byte, f float64, decimals int) []byte {
+	if decimals == ShortestPrecision {
+		return strconv.AppendFloat(dst, f, 'g', -1, 64)
+	}
+	return strconv.AppendFloat(dst, f, 'f', decimals, 64)
 }
 
 // Decodes the current Point from a JSON body.
 // Throws an error if the body of the point cannot be interpreted by the JSON body
+// The keys are taken from JSONFieldNames.
 func (p *Point) UnmarshalJSON(data []byte) error {
 	// TODO throw an error if there is an issue parsing the body.
-	dec := json.NewDecoder(bytes.NewReader(data))
-	var values map[string]float64
-	err := dec.Decode(&values)
+	values, err := decodeJSONValues(data)
 
 	if err != nil {
 		log.Print(err)
 		return err
 	}
 
-	*p = *NewPoint(values["lat"], values["lng"])
+	*p = *NewPoint(values[JSONFieldNames.Lat], values[JSONFieldNames.Lng])
 
 	return nil
 }
+
+// Decodes a Point from a JSON body using the passed in keys for the latitude and longitude.
+// Throws an error if the body cannot be interpreted or either of the keys is missing.
+func UnmarshalJSONKeys(data []byte, latKey, lngKey string) (*Point, error) {
+	values, err := decodeJSONValues(data)
+	if err != nil {
+		return nil, err
+	}
+
+	lat, ok := values[latKey]
+	if !ok {
+		return nil, fmt.Errorf("missing key %q in JSON body", latKey)
+	}
+	lng, ok := values[lngKey]
+	if !ok {
+		return nil, fmt.Errorf("missing key %q in JSON body", lngKey)
+	}
+
+	return NewPoint(lat, lng), nil
+}
+
+// Decodes the numeric values of a JSON object.
+func decodeJSONValues(data []byte) (map[string]float64, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var values map[string]float64
+	err := dec.Decode(&values)
+	return values, err
+}
+
+// Converts the current Point to a unit vector in earth-centered cartesian space.
+func (p *Point) toVector() (x, y, z float64) {
+	lat := p.lat * math.Pi / 180.0
+	lng := p.lng * math.Pi / 180.0
+	return math.Cos(lat) * math.Cos(lng), math.Cos(lat) * math.Sin(lng), math.Sin(lat)
+}
+
+// Returns the Point in the direction of the passed in cartesian vector.
+// The vector does not need to be normalized.
+func pointFromVector(x, y, z float64) *Point {
+	lat := math.Atan2(z, math.Sqrt(x*x+y*y)) * 180.0 / math.Pi
+	lng := math.Atan2(y, x) * 180.0 / math.Pi
+	return NewPoint(lat, lng)
+}
+
+// Returns the point of the great circle segment from a to b closest to the passed in point,
+// and its distance (in sea miles) from the passed in point.
+func nearestPointOnSegment(a, b, p *Point) (*Point, float64) {
+	ax, ay, az := a.toVector()
+	bx, by, bz := b.toVector()
+	px, py, pz := p.toVector()
+
+	// The normal of the great circle through a and b
+	nx, ny, nz := ay*bz-az*by, az*bx-ax*bz, ax*by-ay*bx
+	norm := math.Sqrt(nx*nx + ny*ny + nz*nz)
+	if norm > 0 {
+		nx, ny, nz = nx/norm, ny/norm, nz/norm
+
+		// The closest point of the great circle lies within the segment
+		// if it is on the inner side of both a and b
+		sideA := (ny*az-nz*ay)*px + (nz*ax-nx*az)*py + (nx*ay-ny*ax)*pz
+		sideB := (by*nz-bz*ny)*px + (bz*nx-bx*nz)*py + (bx*ny-by*nx)*pz
+		// Points on the poles of the great circle are equally far from all of it
+		d := nx*px + ny*py + nz*pz
+		if sideA >= 0 && sideB >= 0 && math.Abs(d) < 1 {
+			return pointFromVector(px-d*nx, py-d*ny, pz-d*nz), math.Abs(math.Asin(d)) * EARTHRADIUS
+		}
+	}
+
+	da, db := a.GreatCircleDistance(p), b.GreatCircleDistance(p)
+	if db < da {
+		return b, db
+	}
+	return a, da
+}
+
+// Returns the smallest distance (in sea miles) between the passed in point
+// and the great circle segment from a to b.
+func distanceToSegment(a, b, p *Point) float64 {
+	_, dist := nearestPointOnSegment(a, b, p)
+	return dist
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -66,10 +67,10 @@ func TestFormat(t *testing.T) {
 	}{
 		{*NewPoint(45.699750, -69.733722), DecimalDegrees, "45.699750,-69.733722"},
 		{*NewPoint(45.699750, -69.733722), DecimalMinutes, "N 45 41.985, W 69 44.023"},
-		{*NewPoint(45.699750, -69.733722), DecimalSeconds, "N 45 41 59.100, W 69 41 1.399"},
+		{*NewPoint(45.699750, -69.733722), DecimalSeconds, "N 45 41 59.100, W 69 44 1.399"},
 		{*NewPoint(-45.699750, 69.733722), DecimalDegrees, "-45.699750,69.733722"},
 		{*NewPoint(-45.699750, 69.733722), DecimalMinutes, "S 45 41.985, E 69 44.023"},
-		{*NewPoint(-45.699750, 69.733722), DecimalSeconds, "S 45 41 59.100, E 69 41 1.399"},
+		{*NewPoint(-45.699750, 69.733722), DecimalSeconds, "S 45 41 59.100, E 69 44 1.399"},
 	}
 	for _, tt := range formattests {
 		dd, err := tt.in.Format(tt.inFmt)
@@ -82,6 +83,98 @@ func TestFormat(t *testing.T) {
 	}
 }
 
+// Tests that points round trip through Format and Parse in every format, for longitudes
+// whose minutes differ from those of the latitude
+func TestFormatRoundTrip(t *testing.T) {
+	precisions := map[Format]float64{DecimalDegrees: 1e-6, DecimalMinutes: 1e-3 / 60, DecimalSeconds: 1e-3 / 3600}
+	for i := 0; i < 500; i++ {
+		p := NewPoint(float64(i%179)-89+float64(i%7)/8, float64(i*7%359)-179+float64(i%13)/17)
+		for format, precision := range precisions {
+			s, _ := p.Format(format)
+			parsed, err := Parse(s)
+			if err != nil {
+				t.Fatalf("Expected err to be nil parsing '%s', but got %v instead.", s, err)
+			}
+			if math.Abs(parsed.lat-p.lat) > precision || math.Abs(parsed.lng-p.lng) > precision {
+				t.Errorf("Expected %v formatted as '%s' to parse back, but got %v instead", p, s, parsed)
+			}
+		}
+	}
+}
+
+// Tests that formats can be looked up by their snake_case and CamelCase names
+func TestParseFormatName(t *testing.T) {
+	var nametests = []struct {
+		in  string
+		out Format
+	}{
+		{"decimal_degrees", DecimalDegrees},
+		{"DecimalDegrees", DecimalDegrees},
+		{"decimal_minutes", DecimalMinutes},
+		{"DecimalMinutes", DecimalMinutes},
+		{"decimal_seconds", DecimalSeconds},
+		{"DecimalSeconds", DecimalSeconds},
+	}
+	for _, tt := range nametests {
+		f, err := ParseFormatName(tt.in)
+		if err != nil {
+			t.Errorf("Expected err to be nil, but got %v instead.", err)
+		}
+		if f != tt.out {
+			t.Errorf("Expected format name '%s' to parse as %v, but got %v instead", tt.in, tt.out, f)
+		}
+	}
+
+	_, err := ParseFormatName("degrees_minutes_seconds")
+	if err == nil {
+		t.Fatal("Expected an error for an unknown format name")
+	}
+	for _, name := range []string{"degrees_minutes_seconds", "decimal_degrees", "decimal_minutes", "decimal_seconds"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected the error '%v' to mention '%s'", err, name)
+		}
+	}
+}
+
+// Tests that formats round trip through their text representation, as used by config files
+func TestFormatText(t *testing.T) {
+	type config struct {
+		Output Format
+	}
+
+	for _, f := range []Format{DecimalDegrees, DecimalMinutes, DecimalSeconds} {
+		text, err := f.MarshalText()
+		if err != nil {
+			t.Errorf("Expected err to be nil, but got %v instead.", err)
+		}
+		if string(text) != f.String() {
+			t.Errorf("Expected format %d to marshal as '%s', but got '%s' instead", f, f.String(), text)
+		}
+
+		// Text unmarshalers are also honored by encoding/json
+		var c config
+		if err := json.Unmarshal([]byte(`{"Output":"`+string(text)+`"}`), &c); err != nil {
+			t.Errorf("Expected err to be nil, but got %v instead.", err)
+		}
+		if c.Output != f {
+			t.Errorf("Expected '%s' to unmarshal as %v, but got %v instead", text, f, c.Output)
+		}
+	}
+
+	var f Format
+	if err := f.UnmarshalText([]byte("bogus")); err == nil {
+		t.Error("Expected an error when unmarshalling an unknown format name")
+	}
+
+	if _, err := Format(7).MarshalText(); err == nil {
+		t.Error("Expected an error when marshalling an invalid format")
+	}
+
+	if Format(7).String() != "Format(7)" {
+		t.Errorf("Expected an invalid format to render as 'Format(7)', but got '%s' instead", Format(7).String())
+	}
+}
+
 // Tests that calling GetLat() after creating a new point returns the expected lat value.
 func TestLat(t *testing.T) {
 	p := NewPoint(40.5, 120.5)
@@ -104,6 +197,33 @@ func TestLng(t *testing.T) {
 	}
 }
 
+// Ensures that the batch distances match GreatCircleDistance and that short output slices are rejected.
+func TestGreatCircleDistancesInto(t *testing.T) {
+	origin := NewPoint(40.7486, -73.9864)
+	targets := []*Point{NewPoint(42.3581, -71.0636), NewPoint(-33.8, 151.2), origin}
+
+	out := make([]float64, 5)
+	if err := origin.GreatCircleDistancesInto(targets, out); err != nil {
+		t.Fatal(err)
+	}
+	fresh := origin.GreatCircleDistances(targets)
+	if len(fresh) != len(targets) {
+		t.Fatalf("Expected %d distances, but got %v instead", len(targets), fresh)
+	}
+	for i, target := range targets {
+		if out[i] != origin.GreatCircleDistance(target) || fresh[i] != out[i] {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v to %v, but got %v and %v", origin.GreatCircleDistance(target), target, out[i], fresh[i]))
+		}
+	}
+	if out[3] != 0 || out[4] != 0 {
+		t.Error("Expected the elements past the targets to be left alone, but got", out)
+	}
+
+	if err := origin.GreatCircleDistancesInto(targets, make([]float64, 2)); err == nil {
+		t.Error("Expected an error for an output slice shorter than the targets")
+	}
+}
+
 // Seems brittle :\
 func TestGreatCircleDistance(t *testing.T) {
 	// Test that SEA and SFO are ~ 1091km apart, accurate to 100 meters.
@@ -134,6 +254,299 @@ func TestPointAtDistanceAndBearing(t *testing.T) {
 	}
 }
 
+// Ensures that great circle distances are scaled to the requested unit.
+func TestGreatCircleDistanceIn(t *testing.T) {
+	// SEA and SFO are ~ 1093km apart
+	sea := &Point{lat: 47.4489, lng: -122.3094}
+	sfo := &Point{lat: 37.6160933, lng: -122.3924223}
+	if dist := sea.GreatCircleDistanceIn(sfo, Kilometers); math.Abs(dist-1093.379199082169) > 0.1 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected ~1093.379km, but got %v", dist))
+	}
+	if dist := sea.GreatCircleDistanceIn(sfo, Miles); math.Abs(dist-1093.379199082169/1.609344) > 0.1 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected ~679.39mi, but got %v", dist))
+	}
+	if dist := sea.GreatCircleDistanceIn(sfo, NauticalMiles); dist != sea.GreatCircleDistance(sfo) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v sea miles, but got %v", sea.GreatCircleDistance(sfo), dist))
+	}
+
+	for _, unit := range []Unit{NauticalMiles, Kilometers, Meters, Miles, Feet} {
+		if dist := sea.GreatCircleDistanceIn(NewPoint(sea.lat, sea.lng), unit); dist != 0 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected identical points to be 0%s apart, but got %v", unit, dist))
+		}
+
+		// Antipodal points are half the circumference apart
+		for _, p := range []*Point{NewPoint(0, 0), NewPoint(33.3, 71.7), NewPoint(-80, -170), sea} {
+			antipode := NewPoint(-p.lat, normalizeLongitude(p.lng+180))
+			expected := Distance(EARTHRADIUS * math.Pi).In(unit)
+			if dist := p.GreatCircleDistanceIn(antipode, unit); math.IsNaN(dist) || math.Abs(dist-expected) > expected*1e-6 {
+				t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v to be %v%s from its antipode, but got %v", p, expected, unit, dist))
+			}
+		}
+	}
+}
+
+// Ensures that points are transposed by distances given in any unit.
+func TestPointAtDistanceAndBearingIn(t *testing.T) {
+	sea := &Point{lat: 47.44745785, lng: -122.308065668024}
+	p := sea.PointAtDistanceAndBearingIn(1090.7, 180, Kilometers)
+
+	// ~1091km at bearing of 180 degrees
+	if math.Abs(p.lat-37.638557) > 0.001 || math.Abs(p.lng+122.308066) > 0.001 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("[%f, %f]", p.lat, p.lng))
+	}
+
+	for _, unit := range []Unit{NauticalMiles, Kilometers, Meters, Miles, Feet} {
+		q := sea.PointAtDistanceAndBearingIn(250, 45, unit)
+		if dist := sea.GreatCircleDistanceIn(q, unit); math.Abs(dist-250) > 1e-6 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v to be 250%s away, but got %v", q, unit, dist))
+		}
+	}
+}
+
+// Ensures that distances to an arc are measured to the arc itself, or to its nearest end.
+func TestDistanceToArc(t *testing.T) {
+	start, end := NewPoint(0, 0), NewPoint(0, 10)
+
+	// Abreast of the arc, the distance is the cross track distance
+	p := NewPoint(1, 5)
+	if dist, xte := p.DistanceToArc(start, end), p.CrossTrackError(start, end); math.Abs(dist-math.Abs(xte)) > 1e-9 || dist <= 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the cross track distance %v, but got %v", math.Abs(xte), dist))
+	}
+	p = NewPoint(-2, 3)
+	if dist := p.DistanceToArc(start, end); math.Abs(dist-p.GreatCircleDistance(NewPoint(0, 3))) > 1e-6 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v, but got %v", p.GreatCircleDistance(NewPoint(0, 3)), dist))
+	}
+
+	// Before the start and past the end, the distance is to the nearest end
+	p = NewPoint(1, -3)
+	if dist := p.DistanceToArc(start, end); math.Abs(dist-p.GreatCircleDistance(start)) > 1e-9 || dist <= math.Abs(p.CrossTrackError(start, end)) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v to the start, but got %v", p.GreatCircleDistance(start), dist))
+	}
+	p = NewPoint(-1, 14)
+	if dist := p.DistanceToArc(start, end); math.Abs(dist-p.GreatCircleDistance(end)) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v to the end, but got %v", p.GreatCircleDistance(end), dist))
+	}
+
+	// On the arc, the distance is 0
+	if dist := NewPoint(0, 7).DistanceToArc(start, end); dist > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected 0, but got %v", dist))
+	}
+}
+
+// Ensures that longitude deltas are taken the short way around.
+func TestShortestLongitudeDelta(t *testing.T) {
+	tests := []struct{ lng1, lng2, delta float64 }{
+		{179.9, -179.9, 0.2},
+		{-179.9, 179.9, -0.2},
+		{10, 20, 10},
+		{20, 10, -10},
+		{0, 180, -180},
+		{180, 0, -180},
+		{-170, 170, -20},
+		{0, 720.5, 0.5},
+	}
+
+	for _, test := range tests {
+		if delta := ShortestLongitudeDelta(test.lng1, test.lng2); math.Abs(delta-test.delta) > 1e-9 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("From %v to %v: %v, expected %v", test.lng1, test.lng2, delta, test.delta))
+		}
+	}
+}
+
+// Ensures that the pairwise methods take the short path across the antimeridian.
+func TestAntimeridianShortPath(t *testing.T) {
+	p1 := NewPoint(10, 179.9)
+	p2 := NewPoint(10, -179.9)
+
+	if dist := p1.GreatCircleDistance(p2); dist > 12 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a short distance, got %f", dist))
+	}
+	if bearing := p1.BearingTo(p2); bearing < 85 || bearing > 95 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected to head east, got %f", bearing))
+	}
+	if bearing := p2.BearingTo(p1); bearing < 265 || bearing > 275 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected to head west, got %f", bearing))
+	}
+
+	mid := p1.MidpointTo(p2)
+	if mid.lng < -180 || mid.lng >= 180 || math.Abs(math.Abs(mid.lng)-180) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the midpoint on the antimeridian, got %v", mid))
+	}
+	quarter := p1.IntermediatePointTo(p2, 0.25)
+	if math.Abs(quarter.lng-179.95) > 1e-3 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a quarter of the way at 179.95, got %v", quarter))
+	}
+
+	// Midpoints just off the antimeridian are normalized too
+	for _, lng := range []float64{179.9999999, -179.9999999, 180, -180} {
+		mid := NewPoint(0, lng).MidpointTo(NewPoint(0, lng))
+		if mid.lng < -180 || mid.lng >= 180 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Midpoint of %v with itself not normalized: %v", lng, mid))
+		}
+	}
+}
+
+// Returns the n-vector of the passed in point.
+func nVector(p *Point) [3]float64 {
+	lat, lng := p.lat*math.Pi/180, p.lng*math.Pi/180
+	return [3]float64{math.Cos(lat) * math.Cos(lng), math.Cos(lat) * math.Sin(lng), math.Sin(lat)}
+}
+
+func cross(a, b [3]float64) [3]float64 {
+	return [3]float64{a[1]*b[2] - a[2]*b[1], a[2]*b[0] - a[0]*b[2], a[0]*b[1] - a[1]*b[0]}
+}
+
+func dot(a, b [3]float64) float64 {
+	return a[0]*b[0] + a[1]*b[1] + a[2]*b[2]
+}
+
+// Sweeps pairs of longitudes within a degree of the antimeridian and compares
+// distances, bearings and midpoints against the n-vector formulas.
+func TestAntimeridianMatchesNVector(t *testing.T) {
+	north := [3]float64{0, 0, 1}
+	for lng1 := 179.0; lng1 <= 180.0; lng1 += 0.05 {
+		for lng2 := -180.0; lng2 <= -179.0; lng2 += 0.05 {
+			for _, lats := range [][2]float64{{0, 0}, {45, 45.5}, {-60, -59}} {
+				p1, p2 := NewPoint(lats[0], lng1), NewPoint(lats[1], lng2)
+				a, b := nVector(p1), nVector(p2)
+
+				c := cross(a, b)
+				dist := math.Atan2(math.Sqrt(dot(c, c)), dot(a, b)) * EARTHRADIUS
+				if math.Abs(p1.GreatCircleDistance(p2)-dist) > 1e-6 {
+					t.Fatal("Unnacceptable result.", fmt.Sprintf("Distance %v - %v: %v, expected %v", p1, p2, p1.GreatCircleDistance(p2), dist))
+				}
+
+				if dist > 1e-6 {
+					// The bearing is the angle between the great circle and the meridian at a
+					east, toNorth := cross(north, a), cross(a, cross(north, a))
+					bearing := math.Mod(math.Atan2(dot(c, toNorth), -dot(c, east))*180/math.Pi+360, 360)
+					if math.Abs(BearingDelta(p1.BearingTo(p2), bearing)) > 1e-6 {
+						t.Fatal("Unnacceptable result.", fmt.Sprintf("Bearing %v - %v: %v, expected %v", p1, p2, p1.BearingTo(p2), bearing))
+					}
+				}
+
+				mid := p1.MidpointTo(p2)
+				expected := NewPoint(0, 0)
+				sum := [3]float64{a[0] + b[0], a[1] + b[1], a[2] + b[2]}
+				expected.lat = math.Atan2(sum[2], math.Hypot(sum[0], sum[1])) * 180 / math.Pi
+				expected.lng = math.Atan2(sum[1], sum[0]) * 180 / math.Pi
+				if mid.lng < -180 || mid.lng >= 180 || mid.GreatCircleDistance(expected) > 1e-6 {
+					t.Fatal("Unnacceptable result.", fmt.Sprintf("Midpoint %v - %v: %v, expected %v", p1, p2, mid, expected))
+				}
+			}
+		}
+	}
+}
+
+// Ensures that the weighted midpoint equals the midpoint for equal weights
+// and leans towards the heavier point otherwise.
+func TestWeightedMidpoint(t *testing.T) {
+	p1 := &Point{lat: 52.205, lng: 0.119}
+	p2 := &Point{lat: 48.857, lng: 2.351}
+
+	mid := p1.MidpointTo(p2)
+	p := WeightedMidpoint(p1, 2, p2, 2)
+	if math.Abs(p.lat-mid.lat) > 1e-9 || math.Abs(p.lng-mid.lng) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("[%f, %f], expected [%f, %f]", p.lat, p.lng, mid.lat, mid.lng))
+	}
+
+	// Nine times the weight on p1 puts the result about a tenth of the way to p2
+	p = WeightedMidpoint(p1, 9, p2, 1)
+	fraction := p1.GreatCircleDistance(p) / p1.GreatCircleDistance(p2)
+	if math.Abs(fraction-0.1) > 0.001 || p.GreatCircleDistance(p1.IntermediatePointTo(p2, fraction)) > 0.01 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("[%f, %f] is %f of the way", p.lat, p.lng, fraction))
+	}
+
+	p = WeightedMidpoint(NewPoint(10, 179), 1, NewPoint(10, -179), 1)
+	if math.Abs(math.Abs(p.lng)-180) > 1e-9 || p.lat < 10 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("[%f, %f], expected to be on the antimeridian", p.lat, p.lng))
+	}
+}
+
+// Ensures that the bearing to the nearest pole depends on the hemisphere, with the equator heading south.
+func TestBearingToNearestPole(t *testing.T) {
+	tests := []struct {
+		p       *Point
+		bearing float64
+		pole    string
+	}{
+		{NewPoint(40.7486, -73.9864), 0, "N"},
+		{NewPoint(90, 0), 0, "N"},
+		{NewPoint(-33.8, 151.2), 180, "S"},
+		{NewPoint(-90, 0), 180, "S"},
+		{NewPoint(0, 10), 180, "S"},
+		{NewPoint(1e-12, 10), 0, "N"},
+	}
+
+	for _, test := range tests {
+		bearing, pole := test.p.BearingToNearestPole()
+		if bearing != test.bearing || pole != test.pole {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v and %s for %v, but got %v and %s", test.bearing, test.pole, test.p, bearing, pole))
+		}
+	}
+}
+
+// Ensures that the resultant bearing weights targets by proximity and lets opposite pulls cancel out.
+func TestResultantBearing(t *testing.T) {
+	p := NewPoint(0, 0)
+
+	// East and west cancel out, leaving the pull towards the north
+	bearing := p.ResultantBearing([]*Point{NewPoint(0, 1), NewPoint(0, -1), NewPoint(2, 0)})
+	if math.Abs(bearing) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a bearing of 0, but got %v", bearing))
+	}
+
+	// A target three times as near pulls three times as hard
+	bearing = p.ResultantBearing([]*Point{NewPoint(0, 1.0/3.0), NewPoint(-1, 0), p})
+	if expected := math.Atan2(3, -1) * 180 / math.Pi; math.Abs(bearing-expected) > 1e-3 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a bearing of %v, but got %v", expected, bearing))
+	}
+
+	bearing = p.ResultantBearing([]*Point{NewPoint(-1, -1e-3), NewPoint(0, -2)})
+	if bearing < 180 || bearing >= 360 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a bearing towards the south-west, but got %v", bearing))
+	}
+
+	for _, targets := range [][]*Point{{p}, {NewPoint(0, 1), NewPoint(0, -1)}, {}} {
+		if bearing := p.ResultantBearing(targets); !math.IsNaN(bearing) {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected NaN for %v, but got %v", targets, bearing))
+		}
+	}
+}
+
+// Tests the distances to the equator and the prime meridian in each hemisphere
+func TestDistanceToEquatorAndPrimeMeridian(t *testing.T) {
+	var distancetests = []struct {
+		in            *Point
+		equator       float64
+		primeMeridian float64
+	}{
+		// Distances in degrees of arc
+		{NewPoint(10, 20), 10, 19.6835},
+		{NewPoint(-10, 20), 10, 19.6835},
+		{NewPoint(10, -20), 10, 19.6835},
+		{NewPoint(-10, -20), 10, 19.6835},
+		{NewPoint(0, 30), 0, 30},
+		{NewPoint(0, -30), 0, 30},
+		{NewPoint(45, 0), 45, 0},
+		{NewPoint(60, 150), 60, 30},
+		{NewPoint(-60, -150), 60, 30},
+	}
+
+	degree := EARTHRADIUS * math.Pi / 180.0
+	for _, tt := range distancetests {
+		equator := tt.in.DistanceToEquator()
+		if math.Abs(equator-tt.equator*degree) > 0.1 {
+			t.Errorf("Expected %v to be %f degrees from the equator, but got %f instead", tt.in, tt.equator, equator/degree)
+		}
+
+		meridian := tt.in.DistanceToPrimeMeridian()
+		if math.Abs(meridian-tt.primeMeridian*degree) > 0.1 {
+			t.Errorf("Expected %v to be %f degrees from the prime meridian, but got %f instead", tt.in, tt.primeMeridian, meridian/degree)
+		}
+	}
+}
+
 func TestBearingTo(t *testing.T) {
 	p1 := &Point{lat: 40.7486, lng: -73.9864}
 	p2 := &Point{lat: 0.0, lng: 0.0}
@@ -178,6 +591,142 @@ func TestMidpointTo(t *testing.T) {
 	}
 }
 
+func TestIntermediatePointTo(t *testing.T) {
+	p1 := &Point{lat: 52.205, lng: 0.119}
+	p2 := &Point{lat: 48.857, lng: 2.351}
+
+	// The halfway point must be the midpoint
+	p := p1.IntermediatePointTo(p2, 0.5)
+	m := p1.MidpointTo(p2)
+
+	withinLatBounds := p.lat < m.lat+0.000001 && p.lat > m.lat-0.000001
+	withinLngBounds := p.lng < m.lng+0.000001 && p.lng > m.lng-0.000001
+	if !(withinLatBounds && withinLngBounds) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("[%f, %f]", p.lat, p.lng))
+	}
+
+	start := p1.IntermediatePointTo(p2, 0)
+	end := p1.IntermediatePointTo(p2, 1)
+	if start.GreatCircleDistance(p1) > 0.000001 || end.GreatCircleDistance(p2) > 0.000001 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("[%f, %f] [%f, %f]", start.lat, start.lng, end.lat, end.lng))
+	}
+}
+
+// Ensures that the batch interpolation agrees with IntermediatePointTo and rejects mismatched slices.
+func TestSlerpBatch(t *testing.T) {
+	starts := []*Point{NewPoint(52.205, 0.119), NewPoint(0, 170), NewPoint(-33.8, 151.2)}
+	ends := []*Point{NewPoint(48.857, 2.351), NewPoint(10, -170), NewPoint(-33.8, 151.2)}
+
+	points, err := SlerpBatch(starts, ends, 0.3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != len(starts) {
+		t.Fatalf("Expected %d points, but got %v instead", len(starts), points)
+	}
+	for i := range starts {
+		expected := starts[i].IntermediatePointTo(ends[i], 0.3)
+		if points[i].lat != expected.lat || points[i].lng != expected.lng {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v, but got %v", expected, points[i]))
+		}
+	}
+
+	if _, err := SlerpBatch(starts, ends[:2], 0.3); err == nil {
+		t.Error("Expected an error for mismatched starts and ends")
+	}
+}
+
+// Ensures that an arc which does not cross the antimeridian is kept in a single segment.
+func TestGreatCircleSegments(t *testing.T) {
+	sea := &Point{lat: 47.4489, lng: -122.3094}
+	lhr := &Point{lat: 51.4700, lng: -0.4543}
+
+	segments := sea.GreatCircleSegments(lhr, 1)
+	if len(segments) != 1 {
+		t.Fatalf("Expected SEA to LHR to be a single segment, but got %d instead", len(segments))
+	}
+
+	arc := sea.GreatCircleDistance(lhr) / EARTHRADIUS * 180.0 / math.Pi
+	expected := int(math.Ceil(arc)) + 1
+	if len(segments[0]) != expected {
+		t.Errorf("Expected %d points, but got %d instead", expected, len(segments[0]))
+	}
+
+	if segments[0][0].lat != sea.lat || segments[0][len(segments[0])-1].lng != lhr.lng {
+		t.Error("Expected the segment to start at SEA and end at LHR")
+	}
+}
+
+// Ensures that an arc crossing the antimeridian is split in two segments
+// meeting at the antimeridian.
+func TestGreatCircleSegmentsAcrossAntimeridian(t *testing.T) {
+	nrt := &Point{lat: 35.7720, lng: 140.3929}
+	sfo := &Point{lat: 37.6160933, lng: -122.3924223}
+
+	segments := nrt.GreatCircleSegments(sfo, 1)
+	if len(segments) != 2 {
+		t.Fatalf("Expected NRT to SFO to be split in 2 segments, but got %d instead", len(segments))
+	}
+
+	last := segments[0][len(segments[0])-1]
+	first := segments[1][0]
+	if last.lng != 180 || first.lng != -180 || last.lat != first.lat {
+		t.Error("Unnacceptable result.", fmt.Sprintf("[%f, %f] [%f, %f]", last.lat, last.lng, first.lat, first.lng))
+	}
+
+	for _, segment := range segments {
+		for i := 1; i < len(segment); i++ {
+			if math.Abs(segment[i].lng-segment[i-1].lng) > 180 {
+				t.Errorf("Expected no segment to cross the antimeridian, but %v to %v does", segment[i-1], segment[i])
+			}
+		}
+	}
+}
+
+// Ensures that a great circle arc peaking above the latitude band is split in two parts
+// which end and start on the band boundary.
+func TestRouteWithinLatitudeBand(t *testing.T) {
+	p1 := NewPoint(50, -60)
+	p2 := NewPoint(50, 60)
+
+	parts := p1.RouteWithinLatitudeBand(p2, -60, 60)
+	if len(parts) != 2 {
+		t.Fatalf("Expected the arc to be split in 2 parts, but got %d instead", len(parts))
+	}
+
+	first, second := parts[0], parts[1]
+	if first[0].lat != p1.lat || first[0].lng != p1.lng {
+		t.Errorf("Expected the first part to start at %v, but got %v instead", p1, first[0])
+	}
+	if last := second[len(second)-1]; last.lat != p2.lat || last.lng != p2.lng {
+		t.Errorf("Expected the second part to end at %v, but got %v instead", p2, last)
+	}
+	if first[len(first)-1].lat != 60 || second[0].lat != 60 {
+		t.Error("Expected the parts to end and start on the band boundary")
+	}
+
+	// The arc is symmetric around the prime meridian
+	if math.Abs(first[len(first)-1].lng+second[0].lng) > 0.000001 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%f %f", first[len(first)-1].lng, second[0].lng))
+	}
+
+	for _, part := range parts {
+		for _, p := range part {
+			if p.lat < -60 || p.lat > 60 {
+				t.Errorf("Expected %v to be within the band", p)
+			}
+		}
+	}
+
+	if parts := p1.RouteWithinLatitudeBand(p2, -90, 90); len(parts) != 1 {
+		t.Errorf("Expected a single part within a band spanning the globe, but got %d instead", len(parts))
+	}
+
+	if parts := p1.RouteWithinLatitudeBand(p2, -10, 10); len(parts) != 0 {
+		t.Errorf("Expected no parts within a band the arc never enters, but got %d instead", len(parts))
+	}
+}
+
 // Ensures that a point can be marhalled into JSON
 func TestMarshalJSON(t *testing.T) {
 	p := NewPoint(40.7486, -73.9864)
@@ -208,6 +757,53 @@ func TestUnmarshalJSON(t *testing.T) {
 	}
 }
 
+// Ensures that a point can be round tripped through JSON with custom keys, e.g. for Elasticsearch
+func TestFormatJSONKeys(t *testing.T) {
+	p := NewPoint(40.7486, -73.9864)
+	res, err := p.FormatJSON("lat", "lon")
+	if err != nil {
+		t.Errorf("Expected err to be nil, but got %v instead.", err)
+	}
+
+	if string(res) != `{"lat":40.7486, "lon":-73.9864}` {
+		t.Errorf("Point should correctly format to JSON with custom keys, but got %s instead", res)
+	}
+
+	actual, err := UnmarshalJSONKeys(res, "lat", "lon")
+	if err != nil {
+		t.Errorf("Expected err to be nil, but got %v instead.", err)
+	}
+	if actual.lat != p.lat || actual.lng != p.lng {
+		t.Errorf("Point has mismatched data after Unmarshalling from JSON with custom keys")
+	}
+
+	if _, err := UnmarshalJSONKeys(res, "latitude", "longitude"); err == nil {
+		t.Error("Expected an error when the keys are missing from the JSON body")
+	}
+}
+
+// Ensures that MarshalJSON and UnmarshalJSON honor JSONFieldNames
+func TestJSONFieldNames(t *testing.T) {
+	defer func(names FieldNames) { JSONFieldNames = names }(JSONFieldNames)
+	JSONFieldNames = FieldNames{Lat: "lat", Lng: "lon"}
+
+	res, err := json.Marshal(NewPoint(40.7486, -73.9864))
+	if err != nil {
+		t.Errorf("Expected err to be nil, but got %v instead.", err)
+	}
+	if string(res) != `{"lat":40.7486,"lon":-73.9864}` {
+		t.Errorf("Point should Marshal to JSON using JSONFieldNames, but got %s instead", res)
+	}
+
+	p := &Point{}
+	if err := json.Unmarshal(res, p); err != nil {
+		t.Errorf("Expected err to be nil, but got %v instead.", err)
+	}
+	if p.lat != 40.7486 || p.lng != -73.9864 {
+		t.Errorf("Point has mismatched data after Unmarshalling from JSON using JSONFieldNames")
+	}
+}
+
 // Ensure that a point can be marshalled into slice of binaries
 func TestMarshalBinary(t *testing.T) {
 	lat, long := 40.7486, -73.9864
@@ -266,3 +862,13 @@ func assertPointsEqual(p1, p2 *Point, precision int) bool {
 	roundedLat2, roundedLng2 := int(p2.lat*float64(precision))/precision, int(p2.lng*float64(precision))/precision
 	return roundedLat1 == roundedLat2 && roundedLng1 == roundedLng2
 }
+
+func BenchmarkGreatCircleDistancesInto(b *testing.B) {
+	origin := NewPoint(40.7486, -73.9864)
+	targets := NewPointGrid(NewPoint(30, -80), NewPoint(50, -60), 1, 1).Points()
+	out := make([]float64, len(targets))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		origin.GreatCircleDistancesInto(targets, out)
+	}
+}
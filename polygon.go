@@ -10,21 +10,81 @@ import (
 // It can thus contain holes, and can be self-intersecting.
 type Polygon struct {
 	points []*Point
+	holes  [][]*Point
 }
 
 // Creates and returns a new pointer to a Polygon
 // composed of the passed in points.  Points are
 // considered to be in order such that the last point
 // forms an edge with the first point.
+// The Polygon keeps a copy of the slice, so modifying the slice afterwards does not affect it.
 func NewPolygon(points []*Point) *Polygon {
+	return NewPolygonNoCopy(append([]*Point(nil), points...))
+}
+
+// Creates and returns a new pointer to a Polygon composed of the passed in points like NewPolygon,
+// but using the passed in slice as is to avoid copying it.  The caller must not modify the slice afterwards.
+func NewPolygonNoCopy(points []*Point) *Polygon {
 	return &Polygon{points: points}
 }
 
-// Returns the points of the current Polygon.
+// Creates and returns a new pointer to a Polygon with the passed in outer ring and holes,
+// each given like the points of NewPolygon.  Points within one of the holes are not part of the Polygon.
+// The Polygon keeps copies of the slices, so modifying them afterwards does not affect it.
+func NewPolygonWithHoles(outer []*Point, holes ...[]*Point) *Polygon {
+	p := NewPolygon(outer)
+	for _, hole := range holes {
+		p.holes = append(p.holes, append([]*Point(nil), hole...))
+	}
+	return p
+}
+
+// Returns the points of the current Polygon, without those of its holes.
+// The returned slice is shared with the Polygon and must not be modified.
 func (p *Polygon) Points() []*Point {
 	return p.points
 }
 
+// Returns the rings of the holes of the current Polygon.
+// The returned slices are shared with the Polygon and must not be modified.
+func (p *Polygon) Holes() [][]*Point {
+	return p.holes
+}
+
+// Returns the points of the current Polygon followed by the rings of its holes.
+func (p *Polygon) rings() [][]*Point {
+	return append([][]*Point{p.points}, p.holes...)
+}
+
+// Returns whether or not the current Polygon has the same points and holes as the passed in Polygon, in the same order.
+func (p *Polygon) Equal(other *Polygon) bool {
+	if p == nil || other == nil {
+		return p == other
+	}
+	if len(p.holes) != len(other.holes) {
+		return false
+	}
+	for i, ring := range p.rings() {
+		if !equalRings(ring, other.rings()[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns whether or not the passed in rings have the same points in the same order.
+func equalRings(a, b []*Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, point := range a {
+		if point.lat != b[i].lat || point.lng != b[i].lng {
+			return false
+		}
+	}
+	return true
+}
+
 // Appends the passed in contour to the current Polygon.
 func (p *Polygon) Add(point *Point) {
 	p.points = append(p.points, point)
@@ -43,6 +103,7 @@ func (p *Polygon) IsClosed() bool {
 }
 
 // Returns whether or not the current Polygon contains the passed in Point.
+// Points within one of its holes are not contained.
 func (p *Polygon) Contains(point *Point) bool {
 	if !p.IsClosed() {
 		return false
@@ -59,6 +120,28 @@ func (p *Polygon) Contains(point *Point) bool {
 		}
 	}
 
+	if contains {
+		for _, hole := range p.holes {
+			if ringContains(hole, point) {
+				return false
+			}
+		}
+	}
+
+	return contains
+}
+
+// Returns whether or not the passed in ring contains the passed in point, which must not lie on its edges.
+// Unlike the raycast of Contains, the crossings of a ray towards the east are counted with half open edges,
+// so a ray passing through vertices is not miscounted.
+func ringContains(ring []*Point, p *Point) bool {
+	contains := false
+	for i, a := range ring {
+		b := ring[(i+1)%len(ring)]
+		if (a.lat > p.lat) != (b.lat > p.lat) && p.lng < a.lng+(b.lng-a.lng)*(p.lat-a.lat)/(b.lat-a.lat) {
+			contains = !contains
+		}
+	}
 	return contains
 }
 
@@ -110,3 +193,421 @@ func (p *Polygon) intersectsWithRaycast(point *Point, start *Point, end *Point)
 
 	return raySlope >= diagSlope
 }
+
+// Returns whether or not the segment drawn by the passed in start and end points
+// touches or crosses any edge of the current Polygon or its holes.
+// Edges are treated as straight lines in the lat/lng plane.
+func (p *Polygon) intersectsSegment(start *Point, end *Point) bool {
+	for _, ring := range p.rings() {
+		for i := range ring {
+			prev := ring[len(ring)-1]
+			if i > 0 {
+				prev = ring[i-1]
+			}
+			if segmentsIntersect(start, end, prev, ring[i]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Returns whether or not the segments a1-a2 and b1-b2 touch or cross
+// each other in the lat/lng plane.
+func segmentsIntersect(a1, a2, b1, b2 *Point) bool {
+	d1 := orientation(b1, b2, a1)
+	d2 := orientation(b1, b2, a2)
+	d3 := orientation(a1, a2, b1)
+	d4 := orientation(a1, a2, b2)
+
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+
+	return (d1 == 0 && onSegment(b1, b2, a1)) ||
+		(d2 == 0 && onSegment(b1, b2, a2)) ||
+		(d3 == 0 && onSegment(a1, a2, b1)) ||
+		(d4 == 0 && onSegment(a1, a2, b2))
+}
+
+// Returns the cross product of the vectors a-b and a-c in the lat/lng plane,
+// which is positive for a counter clockwise turn and negative for a clockwise turn.
+func orientation(a, b, c *Point) float64 {
+	return (b.lng-a.lng)*(c.lat-a.lat) - (b.lat-a.lat)*(c.lng-a.lng)
+}
+
+// Returns whether or not the point p, known to be collinear with a and b,
+// lies within the bounds of the segment a-b.
+func onSegment(a, b, p *Point) bool {
+	return math.Min(a.lat, b.lat) <= p.lat && p.lat <= math.Max(a.lat, b.lat) &&
+		math.Min(a.lng, b.lng) <= p.lng && p.lng <= math.Max(a.lng, b.lng)
+}
+
+// Creates and returns a pointer to a new Polygon approximating the circle with the passed in
+// center and radius (in sea miles) by the passed in number of vertices, starting due north and going clockwise.
+func circlePolygon(center *Point, radius float64, segments int) *Polygon {
+	points := make([]*Point, 0, segments)
+	for i := 0; i < segments; i++ {
+		points = append(points, center.PointAtDistanceAndBearing(radius, 360.0*float64(i)/float64(segments)))
+	}
+	return NewPolygonNoCopy(points)
+}
+
+// Returns the smallest and largest latitude and longitude of the points of the current Polygon.
+// Its holes lie within it and do not extend these bounds.
+func (p *Polygon) bounds() (minLat, minLng, maxLat, maxLng float64) {
+	minLat, minLng = math.Inf(1), math.Inf(1)
+	maxLat, maxLng = math.Inf(-1), math.Inf(-1)
+	for _, point := range p.points {
+		minLat, maxLat = math.Min(minLat, point.lat), math.Max(maxLat, point.lat)
+		minLng, maxLng = math.Min(minLng, point.lng), math.Max(maxLng, point.lng)
+	}
+	return minLat, minLng, maxLat, maxLng
+}
+
+// Returns the signed area of the current Polygon in the lat/lng plane (in square degrees),
+// which is positive if its points are in counter clockwise order and negative otherwise.
+// The areas of its holes are subtracted, whichever order their points are in.
+func (p *Polygon) signedArea() float64 {
+	area := ringArea(p.points)
+	for _, hole := range p.holes {
+		if holeArea := math.Abs(ringArea(hole)); area < 0 {
+			area += holeArea
+		} else {
+			area -= holeArea
+		}
+	}
+	return area
+}
+
+// Returns the signed area of the passed in ring in the lat/lng plane (in square degrees),
+// which is positive if its points are in counter clockwise order and negative otherwise.
+func ringArea(ring []*Point) float64 {
+	area := 0.0
+	for i := range ring {
+		prev := ring[len(ring)-1]
+		if i > 0 {
+			prev = ring[i-1]
+		}
+		area += prev.lng*ring[i].lat - ring[i].lng*prev.lat
+	}
+	return area / 2
+}
+
+// Decomposes the current Polygon into triangles using the ear clipping algorithm.
+// The Polygon and its holes have to be simple, i.e. without self-intersections, and the holes must neither
+// touch nor overlap each other.  Each hole is first joined to the outer ring by a bridge to one of its vertices.
+// Edges are treated as straight lines in the lat/lng plane, and all triangles
+// are returned in counter clockwise order, so their planar area is positive.
+// A Polygon of n distinct points, including those of its h holes, yields n+2h-2 triangles.
+func (p *Polygon) Triangulate() [][3]*Point {
+	points := openRing(p.points)
+	if len(points) < 3 {
+		return nil
+	}
+	if len(p.holes) > 0 {
+		points = bridgeHoles(points, p.holes)
+	}
+
+	// Work on the indices of the remaining points, in counter clockwise order
+	remaining := make([]int, len(points))
+	for i := range remaining {
+		remaining[i] = i
+	}
+	if ringArea(points) < 0 {
+		for i, j := 0, len(remaining)-1; i < j; i, j = i+1, j-1 {
+			remaining[i], remaining[j] = remaining[j], remaining[i]
+		}
+	}
+
+	triangles := make([][3]*Point, 0, len(points)-2)
+	for len(remaining) > 3 {
+		ear := -1
+		for i := range remaining {
+			if isEar(points, remaining, i) {
+				ear = i
+				break
+			}
+		}
+		// Only degenerate polygons have no ear, clip the first vertex to make progress
+		if ear == -1 {
+			ear = 0
+		}
+
+		prev, next := (ear+len(remaining)-1)%len(remaining), (ear+1)%len(remaining)
+		triangles = append(triangles, [3]*Point{points[remaining[prev]], points[remaining[ear]], points[remaining[next]]})
+		remaining = append(remaining[:ear], remaining[ear+1:]...)
+	}
+
+	return append(triangles, [3]*Point{points[remaining[0]], points[remaining[1]], points[remaining[2]]})
+}
+
+// Returns the passed in ring without a last point repeating its first one.
+func openRing(ring []*Point) []*Point {
+	if n := len(ring); n > 3 && ring[0].lat == ring[n-1].lat && ring[0].lng == ring[n-1].lng {
+		return ring[:n-1]
+	}
+	return ring
+}
+
+// Joins the passed in holes to the passed in outer ring and returns the resulting single counter clockwise ring.
+// Every hole is run through clockwise, from and back to its easternmost vertex, which is connected by a bridge
+// to the nearest vertex of the ring it can see, so that the bridge crosses no edge.
+// The vertices at both ends of a bridge appear twice in the result.
+func bridgeHoles(outer []*Point, holes [][]*Point) []*Point {
+	ring := append([]*Point{}, outer...)
+	if ringArea(ring) < 0 {
+		reverseRing(ring)
+	}
+	pending := make([][]*Point, 0, len(holes))
+	for _, hole := range holes {
+		hole = append([]*Point{}, openRing(hole)...)
+		if len(hole) < 3 {
+			continue
+		}
+		if ringArea(hole) > 0 {
+			reverseRing(hole)
+		}
+		pending = append(pending, hole)
+	}
+
+	for len(pending) > 0 {
+		hole := pending[0]
+		pending = pending[1:]
+
+		east := 0
+		for i, point := range hole {
+			if point.lng > hole[east].lng {
+				east = i
+			}
+		}
+		m := hole[east]
+
+		bridge, dist := -1, math.Inf(1)
+		for i, v := range ring {
+			d := math.Hypot(v.lat-m.lat, v.lng-m.lng)
+			if d >= dist || !inCone(ring[(i+len(ring)-1)%len(ring)], v, ring[(i+1)%len(ring)], m) {
+				continue
+			}
+			if bridgeCrosses(v, m, ring) || bridgeCrosses(v, m, hole) {
+				continue
+			}
+			crosses := false
+			for _, other := range pending {
+				crosses = crosses || bridgeCrosses(v, m, other)
+			}
+			if !crosses {
+				bridge, dist = i, d
+			}
+		}
+		// Only degenerate holes cannot be seen from any vertex, they are left out
+		if bridge < 0 {
+			continue
+		}
+
+		joined := make([]*Point, 0, len(ring)+len(hole)+2)
+		joined = append(joined, ring[:bridge+1]...)
+		joined = append(joined, hole[east:]...)
+		joined = append(joined, hole[:east+1]...)
+		joined = append(joined, ring[bridge:]...)
+		ring = joined
+	}
+	return ring
+}
+
+// Reverses the order of the points of the passed in ring in place.
+func reverseRing(ring []*Point) {
+	for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+		ring[i], ring[j] = ring[j], ring[i]
+	}
+}
+
+// Returns whether or not the direction from the vertex v towards the point q lies within the interior angle
+// of a counter clockwise ring at v, between the edges from prev and to next.
+func inCone(prev, v, next, q *Point) bool {
+	if orientation(prev, v, next) >= 0 {
+		return orientation(v, q, prev) > 0 && orientation(q, v, next) > 0
+	}
+	return !(orientation(v, q, next) >= 0 && orientation(q, v, prev) >= 0)
+}
+
+// Returns whether or not the bridge between the passed in points touches or crosses an edge of the passed in ring
+// which does not end at either of them.
+func bridgeCrosses(a, b *Point, ring []*Point) bool {
+	for i, c := range ring {
+		d := ring[(i+1)%len(ring)]
+		if c == a || c == b || d == a || d == b {
+			continue
+		}
+		if segmentsIntersect(a, b, c, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns whether or not the i-th of the remaining points forms an ear,
+// i.e. a convex vertex whose triangle with its neighbours contains no other remaining point.
+func isEar(points []*Point, remaining []int, i int) bool {
+	a := points[remaining[(i+len(remaining)-1)%len(remaining)]]
+	b := points[remaining[i]]
+	c := points[remaining[(i+1)%len(remaining)]]
+	if orientation(a, b, c) <= 0 {
+		return false
+	}
+
+	for _, j := range remaining {
+		q := points[j]
+		if q == a || q == b || q == c {
+			continue
+		}
+		if orientation(a, b, q) >= 0 && orientation(b, c, q) >= 0 && orientation(c, a, q) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns a simplified copy of the current Polygon, using the Visvalingam-Whyatt algorithm:
+// the vertex forming the smallest triangle with its neighbours is removed repeatedly, as long
+// as the sum of the removed triangles stays within the passed in area (in square sea miles).
+// The area of the result thus differs from the original by at most that area.
+// Its holes are simplified after the outer ring, within what is left of that area.
+// Areas are measured in an equirectangular projection centered on the Polygon,
+// and at least three vertices are kept of every ring.
+func (p *Polygon) SimplifyByArea(maxAreaLoss float64) *Polygon {
+	minLat, _, maxLat, _ := p.bounds()
+	scale := EARTHRADIUS * math.Pi / 180.0
+	cosLat := math.Cos((minLat + maxLat) / 2 * math.Pi / 180.0)
+	triangleArea := func(a, b, c *Point) float64 {
+		return math.Abs(orientation(a, b, c)) / 2 * scale * scale * cosLat
+	}
+
+	rings := p.rings()
+	simplified := make([][]*Point, len(rings))
+	loss := 0.0
+	for i, ring := range rings {
+		simplified[i], loss = simplifyRing(ring, maxAreaLoss, loss, triangleArea)
+	}
+	return &Polygon{points: simplified[0], holes: simplified[1:]}
+}
+
+// Returns a copy of the passed in ring without the vertices forming the smallest triangles with their neighbours
+// as measured by the passed in function, removed as long as the passed in loss grows to at most the passed in
+// maximum, and the grown loss.  A ring closed by repeating its first point stays closed.
+func simplifyRing(ring []*Point, maxAreaLoss, loss float64, triangleArea func(a, b, c *Point) float64) ([]*Point, float64) {
+	points := openRing(ring)
+	closed := len(points) < len(ring)
+
+	remaining := append([]*Point{}, points...)
+	for len(remaining) > 3 {
+		smallest, area := -1, math.Inf(1)
+		for i := range remaining {
+			prev := remaining[(i+len(remaining)-1)%len(remaining)]
+			next := remaining[(i+1)%len(remaining)]
+			if a := triangleArea(prev, remaining[i], next); a < area {
+				smallest, area = i, a
+			}
+		}
+		if loss+area > maxAreaLoss {
+			break
+		}
+		loss += area
+		remaining = append(remaining[:smallest], remaining[smallest+1:]...)
+	}
+
+	if closed {
+		remaining = append(remaining, remaining[0])
+	}
+	return remaining, loss
+}
+
+// Clips the current Polygon to the box spanned by the passed in south-west and north-east corners,
+// using the Sutherland-Hodgman algorithm, and returns the part of it within the box.
+// All points of the result lie inside of or on the edge of the box.
+// Its holes are clipped alike, and those outside of the box are dropped.
+// Returns nil if the Polygon and the box do not intersect.
+// Edges are treated as straight lines in the lat/lng plane and the box must not cross the antimeridian.
+func (p *Polygon) ClipByBoundingBox(sw, ne *Point) *Polygon {
+	points := clipRingByBoundingBox(p.points, sw, ne)
+	if len(points) < 3 {
+		return nil
+	}
+
+	clipped := NewPolygonNoCopy(points)
+	for _, hole := range p.holes {
+		if hole = clipRingByBoundingBox(hole, sw, ne); len(hole) >= 3 {
+			clipped.holes = append(clipped.holes, hole)
+		}
+	}
+	return clipped
+}
+
+// Returns the part of the passed in ring within the box spanned by the passed in south-west and north-east corners,
+// as clipped by the Sutherland-Hodgman algorithm.
+func clipRingByBoundingBox(points []*Point, sw, ne *Point) []*Point {
+	// Each edge of the box as whether a point is inside of it, and where a segment crosses it
+	edges := []struct {
+		inside   func(q *Point) bool
+		crossing func(a, b *Point) *Point
+	}{
+		{func(q *Point) bool { return q.lat >= sw.lat }, func(a, b *Point) *Point { return latitudeCrossing(a, b, sw.lat) }},
+		{func(q *Point) bool { return q.lng <= ne.lng }, func(a, b *Point) *Point { return longitudeCrossing(a, b, ne.lng) }},
+		{func(q *Point) bool { return q.lat <= ne.lat }, func(a, b *Point) *Point { return latitudeCrossing(a, b, ne.lat) }},
+		{func(q *Point) bool { return q.lng >= sw.lng }, func(a, b *Point) *Point { return longitudeCrossing(a, b, sw.lng) }},
+	}
+
+	for _, edge := range edges {
+		if len(points) == 0 {
+			break
+		}
+		clipped := make([]*Point, 0, len(points)+4)
+		prev := points[len(points)-1]
+		for _, q := range points {
+			switch {
+			case edge.inside(q) && !edge.inside(prev):
+				clipped = append(clipped, edge.crossing(prev, q), q)
+			case edge.inside(q):
+				clipped = append(clipped, q)
+			case edge.inside(prev):
+				clipped = append(clipped, edge.crossing(prev, q))
+			}
+			prev = q
+		}
+		points = clipped
+	}
+	return points
+}
+
+// Returns the point where the segment a-b crosses the passed in latitude, in the lat/lng plane.
+func latitudeCrossing(a, b *Point, lat float64) *Point {
+	t := (lat - a.lat) / (b.lat - a.lat)
+	return NewPoint(lat, a.lng+t*(b.lng-a.lng))
+}
+
+// Returns the point where the segment a-b crosses the passed in longitude, in the lat/lng plane.
+func longitudeCrossing(a, b *Point, lng float64) *Point {
+	t := (lng - a.lng) / (b.lng - a.lng)
+	return NewPoint(a.lat+t*(b.lat-a.lat), lng)
+}
+
+// Returns the point on the edges of the current Polygon or its holes closest to the passed in point,
+// and its distance (in sea miles).  Each edge is treated as a great circle segment.
+// Returns nil and an infinite distance for a Polygon without points.
+func (p *Polygon) NearestBoundaryPoint(point *Point) (*Point, float64) {
+	var nearest *Point
+	dist := math.Inf(1)
+	for _, ring := range p.rings() {
+		for i := range ring {
+			prev := ring[len(ring)-1]
+			if i > 0 {
+				prev = ring[i-1]
+			}
+			if q, d := nearestPointOnSegment(prev, ring[i], point); d < dist {
+				nearest, dist = q, d
+			}
+		}
+	}
+	return nearest, dist
+}
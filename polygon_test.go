@@ -2,6 +2,8 @@ package geo
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
 	"os"
 	"testing"
 )
@@ -128,6 +130,156 @@ func TestEquatorGreenwichContains(t *testing.T) {
 	}
 }
 
+// The box from [0, 0] to [4, 4].
+var square4 = []*Point{NewPoint(0, 0), NewPoint(0, 4), NewPoint(4, 4), NewPoint(4, 0)}
+
+// Returns a counter clockwise square hole of half a degree with its south-west corner at the passed in latitude and longitude.
+func squareHole(lat, lng float64) []*Point {
+	return []*Point{NewPoint(lat, lng), NewPoint(lat, lng+0.5), NewPoint(lat+0.5, lng+0.5), NewPoint(lat+0.5, lng)}
+}
+
+// Ensures that polygons are triangulated into n+2h-2 counter clockwise triangles covering their area.
+func TestTriangulate(t *testing.T) {
+	square := NewPolygon([]*Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 0)})
+	pentagon := NewPolygon([]*Point{NewPoint(0, 0), NewPoint(0, 2), NewPoint(1, 3), NewPoint(2, 2), NewPoint(2, 0)})
+	// A concave "L" shape, closed explicitly and in counter clockwise order
+	concave := NewPolygon([]*Point{NewPoint(0, 0), NewPoint(0, 2), NewPoint(1, 2), NewPoint(1, 1),
+		NewPoint(2, 1), NewPoint(2, 0), NewPoint(0, 0)})
+
+	tests := []struct {
+		polygon   *Polygon
+		triangles int
+	}{
+		{square, 2},
+		{pentagon, 3},
+		{concave, 4},
+		// Clockwise polygons yield counter clockwise triangles too
+		{NewPolygon([]*Point{NewPoint(1, 0), NewPoint(1, 1), NewPoint(0, 1), NewPoint(0, 0)}), 2},
+		// Every hole adds its points and two triangles for its bridge, whichever order its points are in
+		{NewPolygonWithHoles(square4, squareHole(1, 1)), 8},
+		{NewPolygonWithHoles(square4, squareHole(1, 1), squareHole(2.5, 1.5)), 14},
+		{NewPolygonWithHoles(square4, squareHole(2.5, 0.5), squareHole(0.5, 2.5), squareHole(2.5, 2.5)), 20},
+	}
+
+	for _, test := range tests {
+		triangles := test.polygon.Triangulate()
+		if len(triangles) != test.triangles {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %d triangles, got %d", test.triangles, len(triangles)))
+		}
+
+		total := 0.0
+		for _, triangle := range triangles {
+			area := NewPolygon(triangle[:]).signedArea()
+			if area <= 0 {
+				t.Error("Unnacceptable result.", fmt.Sprintf("Triangle %v has a non-positive area %v", triangle, area))
+			}
+			total += area
+		}
+		if expected := math.Abs(test.polygon.signedArea()); math.Abs(total-expected) > 1e-9 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Triangles cover %v, expected %v", total, expected))
+		}
+	}
+}
+
+// Ensures that simplifying by area keeps the Polygon as it is without a tolerance,
+// and reduces a ragged coastline within the area tolerance otherwise.
+func TestSimplifyByArea(t *testing.T) {
+	points := []*Point{}
+	for i := 0; i < 1000; i++ {
+		angle := 2 * math.Pi * float64(i) / 1000
+		r := 1 + 0.02*math.Sin(37*angle) + 0.01*math.Sin(113*angle)
+		points = append(points, NewPoint(50+r*math.Sin(angle), 10+r*math.Cos(angle)))
+	}
+	coastline := NewPolygon(points)
+
+	unchanged := coastline.SimplifyByArea(0)
+	if len(unchanged.Points()) != 1000 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected 1000 points, got %d", len(unchanged.Points())))
+	}
+
+	// A square sea mile is about 1/7000 of the area of the coastline
+	const maxLoss = 1.0
+	simplified := coastline.SimplifyByArea(maxLoss)
+	if n := len(simplified.Points()); n >= 900 || n < 3 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the coastline to be reduced, got %d points", n))
+	}
+
+	// The areas in square degrees, converted to square sea miles around the center latitude
+	scale := EARTHRADIUS * math.Pi / 180 * EARTHRADIUS * math.Pi / 180 * math.Cos(50*math.Pi/180)
+	loss := math.Abs(coastline.signedArea()-simplified.signedArea()) * scale
+	if loss > maxLoss {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected an area loss of at most %v, got %v", maxLoss, loss))
+	}
+
+	if len(coastline.SimplifyByArea(math.Inf(1)).Points()) != 3 {
+		t.Error("Unnacceptable result.", "Expected at least three points to be kept")
+	}
+}
+
+// Ensures that a circle clipped by a smaller box lies within the box and covers it.
+func TestClipByBoundingBox(t *testing.T) {
+	circle := circlePolygon(NewPoint(0, 0), 120, 72)
+	sw, ne := NewPoint(-1, -0.5), NewPoint(0.5, 1)
+
+	clipped := circle.ClipByBoundingBox(sw, ne)
+	if clipped == nil {
+		t.Fatal("Expected the circle to intersect the box")
+	}
+	for _, p := range clipped.Points() {
+		if p.lat < sw.lat || p.lat > ne.lat || p.lng < sw.lng || p.lng > ne.lng {
+			t.Error("Unnacceptable result.", fmt.Sprintf("%v is outside of the box", p))
+		}
+	}
+
+	// The box lies within the circle, so the clipped circle is the box
+	if area := math.Abs(clipped.signedArea()); math.Abs(area-1.5*1.5) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected an area of 2.25, got %v", area))
+	}
+
+	// A box around the whole circle keeps it as it is
+	if whole := circle.ClipByBoundingBox(NewPoint(-5, -5), NewPoint(5, 5)); whole == nil || len(whole.Points()) != 72 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the circle to be kept, got %v", whole))
+	}
+
+	if disjoint := circle.ClipByBoundingBox(NewPoint(10, 10), NewPoint(11, 11)); disjoint != nil {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected nil for a disjoint box, got %v", disjoint.Points()))
+	}
+}
+
+// Ensures that points are snapped onto the closest edge of a Polygon.
+func TestNearestBoundaryPoint(t *testing.T) {
+	square := NewPolygon([]*Point{NewPoint(0, 0), NewPoint(0, 2), NewPoint(2, 2), NewPoint(2, 0)})
+
+	tests := []struct {
+		point, nearest *Point
+	}{
+		// Outside, next to the meridian at 2°E and the equator
+		{NewPoint(1, 3), NewPoint(1, 2)},
+		{NewPoint(-0.5, 0.5), NewPoint(0, 0.5)},
+		// Beyond a corner
+		{NewPoint(-1, -1), NewPoint(0, 0)},
+		// Inside, closest to the meridian at 0°
+		{NewPoint(1, 0.2), NewPoint(1, 0)},
+	}
+
+	for _, test := range tests {
+		// On the sphere the perpendicular onto a meridian does not quite keep the latitude
+		nearest, dist := square.NearestBoundaryPoint(test.point)
+		if nearest.GreatCircleDistance(test.nearest) > 0.05 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Snapped %v to %v, expected %v", test.point, nearest, test.nearest))
+		}
+		if math.Abs(dist-test.point.GreatCircleDistance(nearest)) > 1e-6 || dist > test.point.GreatCircleDistance(test.nearest) {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Distance from %v to %v is %f", test.point, nearest, dist))
+		}
+	}
+
+	// The snapped point lies on the edge, between its ends
+	nearest, _ := square.NearestBoundaryPoint(NewPoint(1.3, 2.7))
+	if math.Abs(nearest.lng-2) > 1e-9 || nearest.lat < 0 || nearest.lat > 2 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v to lie on the eastern edge", nearest))
+	}
+}
+
 // A test struct used to encapsulate and
 // Unmarshal JSON into.
 type testPoints struct {
@@ -154,3 +306,112 @@ func polygonFromFile(filename string) (*Polygon, error) {
 
 	return p, nil
 }
+
+// Ensures that a Polygon is unaffected by later modifications of the slice it was created from.
+func TestNewPolygonCopies(t *testing.T) {
+	points := []*Point{NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10), NewPoint(10, 0)}
+	poly := NewPolygon(points)
+	points[0] = NewPoint(50, 50)
+	if first := poly.Points()[0]; first.lat != 0 || first.lng != 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the first point to remain [0, 0], but got %v", first))
+	}
+
+	// Adding to the Polygon does not write into the caller's backing array either
+	points = make([]*Point, 3, 4)
+	copy(points, []*Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)})
+	NewPolygon(points).Add(NewPoint(1, 0))
+	if extended := points[:4]; extended[3] != nil {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the caller's array to be untouched, but got %v", extended[3]))
+	}
+
+	// Without copying, the Polygon shares the slice
+	points = []*Point{NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10)}
+	shared := NewPolygonNoCopy(points)
+	points[0] = NewPoint(50, 50)
+	if first := shared.Points()[0]; first.lat != 50 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the first point to be [50, 50], but got %v", first))
+	}
+}
+
+// Ensures that polygons are equal when they have the same points in the same order.
+func TestPolygonEqual(t *testing.T) {
+	a := NewPolygon([]*Point{NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10)})
+	b := NewPolygon([]*Point{NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10)})
+	if !a.Equal(b) || !b.Equal(a) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v to equal %v", a.Points(), b.Points()))
+	}
+
+	for _, other := range []*Polygon{
+		NewPolygon([]*Point{NewPoint(0, 10), NewPoint(10, 10), NewPoint(0, 0)}),
+		NewPolygon([]*Point{NewPoint(0, 0), NewPoint(0, 10)}),
+		NewPolygon([]*Point{NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10.000001)}),
+		NewPolygonWithHoles([]*Point{NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10)}, squareHole(1, 5)),
+		nil,
+	} {
+		if a.Equal(other) {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v not to equal %v", a.Points(), other))
+		}
+	}
+
+	var none *Polygon
+	if !none.Equal(nil) {
+		t.Error("Expected nil polygons to be equal")
+	}
+}
+
+// Ensures that a Polygon of points passing back through its first one is still treated as a single contour.
+func TestContainsSingleContour(t *testing.T) {
+	p := NewPolygon([]*Point{
+		NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10), NewPoint(10, 0),
+		NewPoint(0, 0), NewPoint(-10, 0), NewPoint(-10, -10), NewPoint(0, -10),
+	})
+	if !p.Contains(NewPoint(-2, -1)) || !p.Contains(NewPoint(5, 5)) {
+		t.Error("Unnacceptable result.", "Expected both squares to be contained")
+	}
+	if p.Contains(NewPoint(5, -5)) {
+		t.Error("Unnacceptable result.", "Expected [5, -5] not to be contained")
+	}
+}
+
+// Ensures that the holes of a Polygon are taken into account by its methods.
+func TestPolygonWithHoles(t *testing.T) {
+	hole := squareHole(1, 1)
+	p := NewPolygonWithHoles(square4, hole)
+	hole[0] = NewPoint(50, 50)
+	if len(p.Holes()) != 1 || p.Holes()[0][0].lat != 1 {
+		t.Fatal("Unnacceptable result.", fmt.Sprintf("Expected a copy of the hole, but got %v", p.Holes()))
+	}
+
+	if !p.Contains(NewPoint(3, 3)) || p.Contains(NewPoint(1.25, 1.25)) || p.Contains(NewPoint(5, 5)) {
+		t.Error("Unnacceptable result.", "Expected the Polygon to contain [3, 3], but neither its hole nor [5, 5]")
+	}
+	if area := p.signedArea(); math.Abs(area-15.75) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected an area of 15.75, but got %v", area))
+	}
+
+	// The point in the hole is closest to the edge of the hole
+	nearest, _ := p.NearestBoundaryPoint(NewPoint(1.25, 1.4))
+	if nearest.GreatCircleDistance(NewPoint(1.25, 1.5)) > 0.05 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the point to be snapped onto the hole, got %v", nearest))
+	}
+
+	if !p.intersectsSegment(NewPoint(1.25, 0.5), NewPoint(1.25, 1.25)) || p.intersectsSegment(NewPoint(3, 0.5), NewPoint(3, 3.5)) {
+		t.Error("Unnacceptable result.", "Expected only the segment running into the hole to intersect the Polygon")
+	}
+
+	// Boxes keep the holes they cover, and drop the others
+	if clipped := p.ClipByBoundingBox(NewPoint(0.5, 0.5), NewPoint(2, 2)); clipped == nil || len(clipped.Holes()) != 1 ||
+		math.Abs(clipped.signedArea()-2) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the hole to be kept, got %v", clipped))
+	}
+	if clipped := p.ClipByBoundingBox(NewPoint(2, 2), NewPoint(3, 3)); clipped == nil || len(clipped.Holes()) != 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the hole to be dropped, got %v", clipped))
+	}
+
+	if simplified := p.SimplifyByArea(math.Inf(1)); len(simplified.Holes()) != 1 || len(simplified.Holes()[0]) != 3 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the hole to be simplified to a triangle, got %v", simplified.Holes()))
+	}
+	if unchanged := p.SimplifyByArea(0); !unchanged.Equal(p) {
+		t.Error("Unnacceptable result.", "Expected the Polygon to be kept as it is without a tolerance")
+	}
+}
@@ -0,0 +1,165 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// A RegionLookup finds the named region, e.g. a country or state, containing a point.
+// Regions are checked in the order they were loaded, and only those whose bounding box
+// contains the point are tested against their polygons.
+type RegionLookup struct {
+	regions []region
+}
+
+// A single region of a RegionLookup.
+type region struct {
+	name string
+	// The closed rings of all polygons of the region, outer rings and holes alike
+	rings [][]*Point
+
+	minLat, minLng, maxLat, maxLng float64
+}
+
+// The parts of a GeoJSON Feature needed to load a region.
+type regionFeature struct {
+	Properties struct {
+		Name string `json:"name"`
+	} `json:"properties"`
+	Geometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	} `json:"geometry"`
+}
+
+// Loads the regions from the passed in GeoJSON FeatureCollection.
+// Each Feature needs a Polygon or MultiPolygon geometry and a "name" property.
+func LoadRegions(r io.Reader) (*RegionLookup, error) {
+	var collection struct {
+		Type     string          `json:"type"`
+		Features []regionFeature `json:"features"`
+	}
+	if err := json.NewDecoder(r).Decode(&collection); err != nil {
+		return nil, err
+	}
+	if collection.Type != "FeatureCollection" {
+		return nil, errors.New("Expected a FeatureCollection, got: " + collection.Type)
+	}
+
+	rl := &RegionLookup{}
+	for i, feature := range collection.Features {
+		if feature.Properties.Name == "" {
+			return nil, fmt.Errorf("feature %d has no name", i)
+		}
+
+		var polygons [][][][]float64
+		switch feature.Geometry.Type {
+		case "Polygon":
+			var rings [][][]float64
+			if err := json.Unmarshal(feature.Geometry.Coordinates, &rings); err != nil {
+				return nil, fmt.Errorf("feature %d: %v", i, err)
+			}
+			polygons = [][][][]float64{rings}
+		case "MultiPolygon":
+			if err := json.Unmarshal(feature.Geometry.Coordinates, &polygons); err != nil {
+				return nil, fmt.Errorf("feature %d: %v", i, err)
+			}
+		default:
+			return nil, fmt.Errorf("feature %d has unsupported geometry type %q", i, feature.Geometry.Type)
+		}
+
+		reg, err := newRegion(feature.Properties.Name, polygons)
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %v", i, err)
+		}
+		rl.regions = append(rl.regions, reg)
+	}
+
+	return rl, nil
+}
+
+// Prepares a region from the rings of GeoJSON polygons.
+func newRegion(name string, polygons [][][][]float64) (region, error) {
+	reg := region{name: name}
+	reg.minLat, reg.minLng = math.Inf(1), math.Inf(1)
+	reg.maxLat, reg.maxLng = math.Inf(-1), math.Inf(-1)
+	for _, rings := range polygons {
+		for _, positions := range rings {
+			if len(positions) < 4 {
+				return reg, errors.New("a linear ring needs at least four positions")
+			}
+			ring := make([]*Point, 0, len(positions))
+			for _, position := range positions {
+				if len(position) < 2 {
+					return reg, errors.New("a position needs at least two numbers")
+				}
+				ring = append(ring, NewPoint(position[1], position[0]))
+			}
+			first, last := ring[0], ring[len(ring)-1]
+			if first.lat != last.lat || first.lng != last.lng {
+				return reg, errors.New("linear ring is not closed")
+			}
+
+			minLat, minLng, maxLat, maxLng := NewPolygonNoCopy(ring).bounds()
+			reg.minLat, reg.maxLat = math.Min(reg.minLat, minLat), math.Max(reg.maxLat, maxLat)
+			reg.minLng, reg.maxLng = math.Min(reg.minLng, minLng), math.Max(reg.maxLng, maxLng)
+			reg.rings = append(reg.rings, ring)
+		}
+	}
+
+	return reg, nil
+}
+
+// Returns the number of regions loaded.
+func (rl *RegionLookup) Len() int {
+	return len(rl.regions)
+}
+
+// Returns the name of the region containing the passed in point, and whether there is one.
+// Points on the boundary of a region are contained by it; on borders shared by several
+// regions the one loaded first wins.
+func (rl *RegionLookup) Locate(p *Point) (name string, ok bool) {
+	for i := range rl.regions {
+		if rl.regions[i].contains(p) {
+			return rl.regions[i].name, true
+		}
+	}
+	return "", false
+}
+
+// Returns the names of the regions containing the passed in points,
+// with an empty name for each point which is in no region.
+func (rl *RegionLookup) LocateAll(points []*Point) []string {
+	names := make([]string, len(points))
+	for i, p := range points {
+		names[i], _ = rl.Locate(p)
+	}
+	return names
+}
+
+// Returns whether or not the region contains the passed in point, including its boundary.
+// Holes and islands are handled by parity: the point is contained if it lies within an odd number of rings.
+func (r *region) contains(p *Point) bool {
+	if p.lat < r.minLat || p.lat > r.maxLat || p.lng < r.minLng || p.lng > r.maxLng {
+		return false
+	}
+
+	for _, ring := range r.rings {
+		for i := 1; i < len(ring); i++ {
+			if orientation(ring[i-1], ring[i], p) == 0 && onSegment(ring[i-1], ring[i], p) {
+				return true
+			}
+		}
+	}
+
+	contains := false
+	for _, ring := range r.rings {
+		if ringContains(ring, p) {
+			contains = !contains
+		}
+	}
+	return contains
+}
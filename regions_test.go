@@ -0,0 +1,139 @@
+package geo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Three fake rectangular countries: West and East side by side, with North spanning both.
+// West has a lake in it.
+const regionsFixture = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"name": "West"}, "geometry": {"type": "Polygon", "coordinates": [
+		[[0, 0], [10, 0], [10, 10], [0, 10], [0, 0]],
+		[[2, 2], [2, 4], [4, 4], [4, 2], [2, 2]]
+	]}},
+	{"type": "Feature", "properties": {"name": "East"}, "geometry": {"type": "Polygon", "coordinates": [
+		[[10, 0], [20, 0], [20, 10], [10, 10], [10, 0]]
+	]}},
+	{"type": "Feature", "properties": {"name": "North"}, "geometry": {"type": "MultiPolygon", "coordinates": [
+		[[[0, 10], [20, 10], [20, 20], [0, 20], [0, 10]]]
+	]}}
+]}`
+
+// Ensures that interior, border and ocean points are located in the expected regions.
+func TestRegionLookupLocate(t *testing.T) {
+	rl, err := LoadRegions(strings.NewReader(regionsFixture))
+	if err != nil {
+		t.Fatal("Failed to load the regions:", err)
+	}
+	if rl.Len() != 3 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected 3 regions, got %d", rl.Len()))
+	}
+
+	tests := []struct {
+		point *Point
+		name  string
+		ok    bool
+	}{
+		{NewPoint(5, 5), "West", true},
+		{NewPoint(5, 15), "East", true},
+		{NewPoint(15, 5), "North", true},
+		// Borders shared by regions go to the one loaded first
+		{NewPoint(5, 10), "West", true},
+		{NewPoint(10, 5), "West", true},
+		{NewPoint(10, 10), "West", true},
+		{NewPoint(10, 15), "East", true},
+		// Outer borders belong to the region
+		{NewPoint(20, 20), "North", true},
+		{NewPoint(0, 5), "West", true},
+		// The lake and the ocean
+		{NewPoint(3, 3), "", false},
+		{NewPoint(3, 2), "West", true},
+		{NewPoint(-5, -5), "", false},
+		{NewPoint(5, 25), "", false},
+	}
+
+	for _, test := range tests {
+		name, ok := rl.Locate(test.point)
+		if name != test.name || ok != test.ok {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v to be in %q (%v), got %q (%v)", test.point, test.name, test.ok, name, ok))
+		}
+	}
+}
+
+// An archipelago of three square islands, and an atoll with two lagoons.
+const islandsFixture = `{"type": "FeatureCollection", "features": [
+	{"type": "Feature", "properties": {"name": "Islands"}, "geometry": {"type": "MultiPolygon", "coordinates": [
+		[[[0, 0], [1, 0], [1, 1], [0, 1], [0, 0]]],
+		[[[4, 4], [5, 4], [5, 5], [4, 5], [4, 4]]],
+		[[[8, 0], [9, 0], [9, 1], [8, 1], [8, 0]]]
+	]}},
+	{"type": "Feature", "properties": {"name": "Atoll"}, "geometry": {"type": "Polygon", "coordinates": [
+		[[0, 10], [10, 10], [10, 20], [0, 20], [0, 10]],
+		[[2, 12], [2, 14], [4, 14], [4, 12], [2, 12]],
+		[[8, 16], [6, 16], [6, 18], [8, 18], [8, 16]]
+	]}}
+]}`
+
+// Ensures that regions made up of three or more rings are located correctly.
+func TestRegionLookupLocateRings(t *testing.T) {
+	rl, err := LoadRegions(strings.NewReader(islandsFixture))
+	if err != nil {
+		t.Fatal("Failed to load the regions:", err)
+	}
+
+	tests := []struct {
+		point *Point
+		name  string
+		ok    bool
+	}{
+		{NewPoint(0.5, 0.7), "Islands", true},
+		{NewPoint(4.5, 4.5), "Islands", true},
+		{NewPoint(0.5, 8.5), "Islands", true},
+		{NewPoint(0.5, 3), "", false},
+		{NewPoint(2, 4), "", false},
+		{NewPoint(1.5, 6), "", false},
+		{NewPoint(11, 1), "Atoll", true},
+		{NewPoint(15, 5), "Atoll", true},
+		{NewPoint(19, 9), "Atoll", true},
+		{NewPoint(12.5, 3), "", false},
+		{NewPoint(13, 3), "", false},
+		{NewPoint(17, 7), "", false},
+	}
+
+	for _, test := range tests {
+		name, ok := rl.Locate(test.point)
+		if name != test.name || ok != test.ok {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v to be in %q (%v), got %q (%v)", test.point, test.name, test.ok, name, ok))
+		}
+	}
+}
+
+// Ensures that LocateAll returns the names in the order of the points.
+func TestRegionLookupLocateAll(t *testing.T) {
+	rl, err := LoadRegions(strings.NewReader(regionsFixture))
+	if err != nil {
+		t.Fatal("Failed to load the regions:", err)
+	}
+
+	names := rl.LocateAll([]*Point{NewPoint(15, 15), NewPoint(-1, 0), NewPoint(1, 19)})
+	if len(names) != 3 || names[0] != "North" || names[1] != "" || names[2] != "East" {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%v", names))
+	}
+}
+
+// Ensures that invalid regions are rejected.
+func TestLoadRegionsInvalid(t *testing.T) {
+	for _, data := range []string{
+		`{"type": "Feature"}`,
+		`{"type": "FeatureCollection", "features": [{"properties": {}, "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}}]}`,
+		`{"type": "FeatureCollection", "features": [{"properties": {"name": "A"}, "geometry": {"type": "Point", "coordinates": [0, 0]}}]}`,
+		`{"type": "FeatureCollection", "features": [{"properties": {"name": "A"}, "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1]]]}}]}`,
+		`not json`,
+	} {
+		if _, err := LoadRegions(strings.NewReader(data)); err == nil {
+			t.Error("Expected an error loading", data)
+		}
+	}
+}
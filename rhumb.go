@@ -0,0 +1,86 @@
+package geo
+
+import (
+	"errors"
+	"math"
+)
+
+// Calculates the projected latitude difference (Δψ) between two latitudes in radians,
+// i.e. the distance between them on a Mercator projection.
+// Original Implementation from: http://www.movable-type.co.uk/scripts/latlong.html
+func projectedLatitudeDelta(lat1 float64, lat2 float64) float64 {
+	return math.Log(math.Tan(math.Pi/4+lat2/2) / math.Tan(math.Pi/4+lat1/2))
+}
+
+// Returns the Point at which the rhumb line (loxodrome) starting at Point p
+// with the passed in compass bearing (in degrees) reaches the passed in latitude (in degrees).
+// Returns an error if the rhumb line never reaches that latitude, e.g. for
+// a due east or west bearing, or a bearing heading away from the latitude.
+// Original Implementation from: http://www.movable-type.co.uk/scripts/latlong.html
+func (p *Point) RhumbCrossingLatitude(bearing float64, latDeg float64) (*Point, error) {
+	if latDeg < -90 || latDeg > 90 {
+		return nil, errors.New("Latitude out of range")
+	}
+
+	if latDeg == p.lat {
+		return NewPoint(p.lat, p.lng), nil
+	}
+
+	theta := bearing * math.Pi / 180.0
+	northing := math.Cos(theta)
+	if math.Abs(northing) < 1e-12 {
+		return nil, errors.New("A due east or west rhumb line never changes latitude")
+	}
+
+	if (latDeg > p.lat) != (northing > 0) {
+		return nil, errors.New("The rhumb line heads away from the passed in latitude")
+	}
+
+	// Following the meridian, the longitude never changes
+	if math.Abs(math.Sin(theta)) < 1e-12 {
+		return NewPoint(latDeg, p.lng), nil
+	}
+
+	if latDeg == 90 || latDeg == -90 {
+		return nil, errors.New("A rhumb line that is not following a meridian spirals around the pole without reaching it")
+	}
+
+	lat1 := p.lat * math.Pi / 180.0
+	lat2 := latDeg * math.Pi / 180.0
+
+	dLng := math.Tan(theta) * projectedLatitudeDelta(lat1, lat2)
+	lng2 := p.lng*math.Pi/180.0 + dLng
+	lng2 = math.Mod(lng2+3*math.Pi, 2*math.Pi) - math.Pi
+
+	return NewPoint(latDeg, lng2*180.0/math.Pi), nil
+}
+
+// Calculates the length (in sea miles) of the rhumb line (loxodrome) starting at Point p
+// with the passed in compass bearing (in degrees) until it has travelled once around the globe,
+// i.e. until its longitude has changed by 360 degrees.
+// Every rhumb line that is not due east or west spirals into a pole after a finite distance,
+// which is reported by reachesPole.  A rhumb line following a meridian never completes
+// a circuit, for it the distance to the pole it heads for is returned.
+// Original Implementation from: http://www.movable-type.co.uk/scripts/latlong.html
+func (p *Point) RhumbCircumnavigation(bearing float64) (length float64, reachesPole bool) {
+	theta := bearing * math.Pi / 180.0
+	lat1 := p.lat * math.Pi / 180.0
+	northing := math.Cos(theta)
+
+	// Following a parallel the whole way round
+	if math.Abs(northing) < 1e-12 {
+		return 2 * math.Pi * math.Abs(math.Cos(lat1)) * EARTHRADIUS, false
+	}
+
+	pole := math.Copysign(math.Pi/2, northing)
+	if math.Abs(math.Sin(theta)) < 1e-12 {
+		return math.Abs(pole-lat1) * EARTHRADIUS, true
+	}
+
+	// The projected latitude change needed for a longitude change of 2π
+	dPsi := 2 * math.Pi / math.Abs(math.Tan(theta))
+	psi2 := math.Log(math.Tan(math.Pi/4+lat1/2)) + math.Copysign(dPsi, northing)
+	lat2 := 2*math.Atan(math.Exp(psi2)) - math.Pi/2
+
+	return math.Abs((lat2-lat1)/northing) * EARTHRADIUS, true
+}
@@ -0,0 +1,105 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Ensures that a northbound rhumb line reaches the target latitude at the expected longitude.
+func TestRhumbCrossingLatitude(t *testing.T) {
+	p := NewPoint(10, 20)
+
+	// Bearing 45 degrees from 10N to 40N, on a mercator projection
+	// the longitude change equals the projected latitude change.
+	crossing, err := p.RhumbCrossingLatitude(45, 40)
+	if err != nil {
+		t.Errorf("Expected err to be nil, but got %v instead.", err)
+	}
+
+	dPsi := projectedLatitudeDelta(10*math.Pi/180.0, 40*math.Pi/180.0) * 180.0 / math.Pi
+	resultLng := 20 + dPsi
+
+	if crossing.lat != 40 || math.Abs(crossing.lng-resultLng) > 0.000001 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("[%f, %f]", crossing.lat, crossing.lng))
+	}
+
+	// Expected crossing ~53.6603°E
+	if math.Abs(crossing.lng-53.6603) > 0.001 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("[%f, %f]", crossing.lat, crossing.lng))
+	}
+
+	due, err := p.RhumbCrossingLatitude(0, 50)
+	if err != nil || due.lng != 20 || due.lat != 50 {
+		t.Errorf("Expected a due north rhumb line to follow the meridian, but got %v, %v instead", due, err)
+	}
+}
+
+// Ensures that latitudes that are never reached produce an error.
+func TestRhumbCrossingLatitudeErrors(t *testing.T) {
+	p := NewPoint(10, 20)
+
+	if _, err := p.RhumbCrossingLatitude(90, 40); err == nil {
+		t.Error("Expected a due east bearing to never reach another latitude")
+	}
+
+	if _, err := p.RhumbCrossingLatitude(270, 40); err == nil {
+		t.Error("Expected a due west bearing to never reach another latitude")
+	}
+
+	if _, err := p.RhumbCrossingLatitude(45, -40); err == nil {
+		t.Error("Expected a northbound bearing to never reach a southern latitude")
+	}
+
+	if _, err := p.RhumbCrossingLatitude(45, 90); err == nil {
+		t.Error("Expected a diagonal bearing to never reach the pole")
+	}
+}
+
+// Ensures that a due east rhumb line along the equator measures the equatorial circumference.
+func TestRhumbCircumnavigationDueEast(t *testing.T) {
+	p := NewPoint(0, 20)
+
+	length, reachesPole := p.RhumbCircumnavigation(90)
+	expected := 2 * math.Pi * EARTHRADIUS
+
+	if reachesPole {
+		t.Error("Expected a due east rhumb line to never reach a pole")
+	}
+	if math.Abs(length-expected) > 0.000001 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%f", length))
+	}
+
+	// Along the 60th parallel the circuit is half as long
+	length, _ = NewPoint(60, 0).RhumbCircumnavigation(270)
+	if math.Abs(length-expected/2) > 0.000001 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%f", length))
+	}
+}
+
+// Ensures that a diagonal rhumb line spirals towards the pole,
+// completing a circuit in less than the distance to the pole along it.
+func TestRhumbCircumnavigationDiagonal(t *testing.T) {
+	p := NewPoint(0, 20)
+
+	length, reachesPole := p.RhumbCircumnavigation(45)
+	if !reachesPole {
+		t.Error("Expected a diagonal rhumb line to reach a pole")
+	}
+
+	// From the equator a 360 degree longitude change at 45 degrees
+	// takes a projected latitude change of 2π, ending at ~89.78°N.
+	lat2 := 2*math.Atan(math.Exp(2*math.Pi)) - math.Pi/2
+	expected := lat2 / math.Cos(math.Pi/4) * EARTHRADIUS
+	toPole := math.Pi / 2 / math.Cos(math.Pi/4) * EARTHRADIUS
+
+	if math.Abs(length-expected) > 0.000001 || length >= toPole {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%f", length))
+	}
+
+	// Heading due south never circles the globe, but reaches the south pole
+	length, reachesPole = p.RhumbCircumnavigation(180)
+	if !reachesPole || math.Abs(length-math.Pi/2*EARTHRADIUS) > 0.000001 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%f", length))
+	}
+}
@@ -0,0 +1,88 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// A Route is a named line of great circle legs along which positions are referenced
+// by their distance from the start, as in linear referencing of roads and railways.
+type Route struct {
+	Name string
+	// How far (in sea miles) a point may be from the Route to be measured along it
+	SnapTolerance float64
+
+	points []*Point
+	// The distance from the start to each point (in sea miles)
+	distances []float64
+}
+
+// Creates and returns a pointer to a new Route with the passed in name along the passed in points.
+// Points farther than snapTolerance (in sea miles) from the Route can not be measured along it.
+func NewRoute(name string, points []*Point, snapTolerance float64) (*Route, error) {
+	if len(points) < 2 {
+		return nil, errors.New("a route needs at least two points")
+	}
+
+	distances := make([]float64, len(points))
+	for i := 1; i < len(points); i++ {
+		distances[i] = distances[i-1] + points[i-1].GreatCircleDistance(points[i])
+	}
+	return &Route{Name: name, SnapTolerance: snapTolerance, points: points, distances: distances}, nil
+}
+
+// Returns the length of the Route (in sea miles).
+func (r *Route) Length() float64 {
+	return r.distances[len(r.distances)-1]
+}
+
+// Snaps the passed in point onto the Route and returns the distance along the Route to the snapped point,
+// and the offset of the point from the Route, positive to the right and negative to the left
+// of the direction of travel (both in sea miles).
+// Returns an error if the point is farther than the Route's SnapTolerance from it.
+func (r *Route) Measure(p *Point) (dist float64, offset float64, err error) {
+	offset = math.Inf(1)
+	for i := 1; i < len(r.points); i++ {
+		nearest, d := nearestPointOnSegment(r.points[i-1], r.points[i], p)
+		if d < math.Abs(offset) {
+			dist = r.distances[i-1] + r.points[i-1].GreatCircleDistance(nearest)
+			offset = d
+			if isLeftOf(r.points[i-1], r.points[i], p) {
+				offset = -d
+			}
+		}
+	}
+
+	if math.Abs(offset) > r.SnapTolerance {
+		return 0, 0, fmt.Errorf("%v is %f sea miles from route %s, more than the tolerance of %f", p, math.Abs(offset), r.Name, r.SnapTolerance)
+	}
+	return dist, offset, nil
+}
+
+// Returns the point at the passed in distance (in sea miles) along the Route.
+// Returns an error if the distance is negative or beyond the end of the Route.
+func (r *Route) Locate(dist float64) (*Point, error) {
+	if dist < 0 || dist > r.Length() {
+		return nil, fmt.Errorf("distance %f is not on route %s of length %f", dist, r.Name, r.Length())
+	}
+
+	for i := 1; i < len(r.points); i++ {
+		if dist <= r.distances[i] {
+			leg := r.distances[i] - r.distances[i-1]
+			if leg == 0 {
+				return NewPoint(r.points[i].lat, r.points[i].lng), nil
+			}
+			return r.points[i-1].IntermediatePointTo(r.points[i], (dist-r.distances[i-1])/leg), nil
+		}
+	}
+	return NewPoint(r.points[len(r.points)-1].lat, r.points[len(r.points)-1].lng), nil
+}
+
+// Returns whether the passed in point lies to the left of the great circle from a to b.
+func isLeftOf(a, b, p *Point) bool {
+	ax, ay, az := a.toVector()
+	bx, by, bz := b.toVector()
+	px, py, pz := p.toVector()
+	return (ay*bz-az*by)*px+(az*bx-ax*bz)*py+(ax*by-ay*bx)*pz > 0
+}
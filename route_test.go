@@ -0,0 +1,78 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Returns a curvy route winding north-east.
+func curvyRoute(t *testing.T) *Route {
+	points := []*Point{}
+	for i := 0; i <= 40; i++ {
+		points = append(points, NewPoint(50+0.05*float64(i), 8+0.1*float64(i)+0.2*math.Sin(float64(i)/3)))
+	}
+	route, err := NewRoute("R1", points, 1)
+	if err != nil {
+		t.Fatal("Unexpected error:", err)
+	}
+	return route
+}
+
+// Ensures that measuring a located point returns its distance along the route.
+func TestRouteMeasureLocateRoundTrip(t *testing.T) {
+	route := curvyRoute(t)
+
+	for dist := 0.0; dist <= route.Length(); dist += route.Length() / 97 {
+		p, err := route.Locate(dist)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		measured, offset, err := route.Measure(p)
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if math.Abs(measured-dist) > 1e-6 || math.Abs(offset) > 1e-6 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Located %f at %v, measured %f with an offset of %f", dist, p, measured, offset))
+		}
+	}
+}
+
+// Ensures that offsets are signed by the side of the route and limited by the snap tolerance.
+func TestRouteMeasureOffset(t *testing.T) {
+	route, _ := NewRoute("Equator", []*Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(0, 2)}, 1)
+
+	// Half a sea mile north of 90 sea miles east, to the left of the eastward route
+	north := NewPoint(0.5/EARTHRADIUS*180/math.Pi, 1.5)
+	dist, offset, err := route.Measure(north)
+	if err != nil || math.Abs(dist-route.Length()*0.75) > 1e-6 || math.Abs(offset+0.5) > 1e-6 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Measured %f with an offset of %f (%v)", dist, offset, err))
+	}
+	_, offset, _ = route.Measure(NewPoint(-north.lat, 0.5))
+	if math.Abs(offset-0.5) > 1e-6 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected an offset of 0.5 to the right, got %f", offset))
+	}
+
+	if _, _, err := route.Measure(NewPoint(0.1, 1)); err == nil {
+		t.Error("Expected an error for a point 6 sea miles off the route")
+	}
+}
+
+// Ensures that distances beyond the route are rejected.
+func TestRouteLocateOutOfRange(t *testing.T) {
+	route := curvyRoute(t)
+	for _, dist := range []float64{-0.001, route.Length() + 0.001} {
+		if _, err := route.Locate(dist); err == nil {
+			t.Error("Expected an error locating", dist)
+		}
+	}
+
+	end, err := route.Locate(route.Length())
+	if err != nil || end.GreatCircleDistance(route.points[len(route.points)-1]) > 1e-6 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the end of the route, got %v (%v)", end, err))
+	}
+
+	if _, err := NewRoute("Short", []*Point{NewPoint(0, 0)}, 1); err == nil {
+		t.Error("Expected an error for a route of a single point")
+	}
+}
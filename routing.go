@@ -0,0 +1,280 @@
+package geo
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+)
+
+// RouteOptions configures the search performed by RouteAvoiding.
+// Zero values are replaced by their defaults.
+type RouteOptions struct {
+	// Maximum spacing of the returned points in degrees of arc, defaults to 1.
+	ResolutionDeg float64
+	// Spacing of the search grid in degrees, defaults to 0.5.
+	GridDeg float64
+	// How far in degrees the search may stray outside of the box
+	// spanned by the start and end points, defaults to 10.
+	MarginDeg float64
+}
+
+// Returns the options with all zero values replaced by their defaults.
+func (o RouteOptions) withDefaults() RouteOptions {
+	if o.ResolutionDeg <= 0 {
+		o.ResolutionDeg = 1
+	}
+	if o.GridDeg <= 0 {
+		o.GridDeg = 0.5
+	}
+	if o.MarginDeg <= 0 {
+		o.MarginDeg = 10
+	}
+	return o
+}
+
+// Finds a route from one point to another that does not enter any of the passed in
+// exclusion Polygons, returned as great circle legs densified to opts.ResolutionDeg.
+// If the direct great circle is clear it is returned as is, otherwise an A* search over a grid
+// of opts.GridDeg degrees around the two points is performed and its result straightened
+// where possible.  The route is valid, but not necessarily the shortest one.
+// Exclusion edges are treated as straight lines in the lat/lng plane and must not cross the antimeridian.
+// Returns an error if either point lies in an exclusion or no route exists within the search bounds.
+func RouteAvoiding(from, to *Point, exclusions []*Polygon, opts RouteOptions) ([]*Point, error) {
+	opts = opts.withDefaults()
+
+	for _, exclusion := range exclusions {
+		if exclusion.Contains(from) || exclusion.Contains(to) {
+			return nil, errors.New("Unable to route from or to a point inside of an exclusion")
+		}
+	}
+
+	if legIsClear(from, to, exclusions, opts.ResolutionDeg) {
+		return from.greatCirclePoints(to, opts.ResolutionDeg), nil
+	}
+
+	waypoints := searchGrid(from, to, exclusions, opts)
+	if waypoints == nil {
+		return nil, errors.New("Unable to find a route within the search bounds")
+	}
+
+	// Pull the grid path straight by skipping every waypoint
+	// that can be bypassed without touching an exclusion.
+	route := []*Point{}
+	for i := 0; i < len(waypoints)-1; {
+		j := len(waypoints) - 1
+		for j > i+1 && !legIsClear(waypoints[i], waypoints[j], exclusions, opts.ResolutionDeg) {
+			j--
+		}
+		leg := waypoints[i].greatCirclePoints(waypoints[j], opts.ResolutionDeg)
+		if len(route) > 0 {
+			leg = leg[1:]
+		}
+		route = append(route, leg...)
+		i = j
+	}
+
+	return route, nil
+}
+
+// Returns whether or not the great circle leg between the passed in points,
+// densified to resolutionDeg, stays outside of all of the exclusions.
+func legIsClear(from, to *Point, exclusions []*Polygon, resolutionDeg float64) bool {
+	points := from.greatCirclePoints(to, resolutionDeg)
+	for _, exclusion := range exclusions {
+		for i := 1; i < len(points); i++ {
+			if exclusion.intersectsSegment(points[i-1], points[i]) || exclusion.Contains(points[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// A node of the search grid, identified by its row and column.
+type gridNode struct {
+	row, col int
+}
+
+// Performs an A* search from one point to another over a grid of points around them,
+// returning the waypoints of the cheapest route found or nil if there is none.
+func searchGrid(from, to *Point, exclusions []*Polygon, opts RouteOptions) []*Point {
+	step := opts.GridDeg
+	minLat := math.Max(math.Min(from.lat, to.lat)-opts.MarginDeg, -90+step)
+	maxLat := math.Min(math.Max(from.lat, to.lat)+opts.MarginDeg, 90-step)
+	minLng := math.Max(math.Min(from.lng, to.lng)-opts.MarginDeg, -180)
+	maxLng := math.Min(math.Max(from.lng, to.lng)+opts.MarginDeg, 180)
+
+	rows := int(math.Floor((maxLat-minLat)/step)) + 1
+	cols := int(math.Floor((maxLng-minLng)/step)) + 1
+
+	// The start and end points are stored alongside the grid nodes
+	// in the rows directly after the grid.
+	start := gridNode{rows, 0}
+	end := gridNode{rows + 1, 0}
+
+	point := func(n gridNode) *Point {
+		switch n {
+		case start:
+			return from
+		case end:
+			return to
+		}
+		return NewPoint(minLat+float64(n.row)*step, minLng+float64(n.col)*step)
+	}
+
+	blocked := map[gridNode]bool{}
+	isBlocked := func(n gridNode) bool {
+		b, ok := blocked[n]
+		if !ok {
+			p := point(n)
+			for _, exclusion := range exclusions {
+				if exclusion.Contains(p) {
+					b = true
+					break
+				}
+			}
+			blocked[n] = b
+		}
+		return b
+	}
+
+	// The grid nodes of the cell containing the passed in point and its neighbours
+	nearby := func(p *Point) []gridNode {
+		row := int(math.Floor((p.lat - minLat) / step))
+		col := int(math.Floor((p.lng - minLng) / step))
+		nodes := []gridNode{}
+		for r := row - 1; r <= row+2; r++ {
+			for c := col - 1; c <= col+2; c++ {
+				if r >= 0 && r < rows && c >= 0 && c < cols {
+					nodes = append(nodes, gridNode{r, c})
+				}
+			}
+		}
+		return nodes
+	}
+	endNeighbours := map[gridNode]bool{}
+	for _, n := range nearby(to) {
+		endNeighbours[n] = true
+	}
+
+	neighbours := func(n gridNode) []gridNode {
+		if n == start {
+			return nearby(from)
+		}
+		nodes := []gridNode{}
+		for dr := -1; dr <= 1; dr++ {
+			for dc := -1; dc <= 1; dc++ {
+				r, c := n.row+dr, n.col+dc
+				if (dr != 0 || dc != 0) && r >= 0 && r < rows && c >= 0 && c < cols {
+					nodes = append(nodes, gridNode{r, c})
+				}
+			}
+		}
+		if endNeighbours[n] {
+			nodes = append(nodes, end)
+		}
+		return nodes
+	}
+
+	cost := map[gridNode]float64{start: 0}
+	previous := map[gridNode]gridNode{}
+	open := &nodeQueue{}
+	heap.Push(open, &queuedNode{node: start, priority: from.GreatCircleDistance(to)})
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(*queuedNode).node
+		if current == end {
+			waypoints := []*Point{to}
+			for current != start {
+				current = previous[current]
+				waypoints = append([]*Point{point(current)}, waypoints...)
+			}
+			return waypoints
+		}
+
+		for _, next := range neighbours(current) {
+			if next != end && isBlocked(next) {
+				continue
+			}
+			a, b := point(current), point(next)
+			c := cost[current] + a.GreatCircleDistance(b)
+			if known, ok := cost[next]; ok && known <= c {
+				continue
+			}
+			if !legIsClear(a, b, exclusions, opts.ResolutionDeg) {
+				continue
+			}
+			cost[next] = c
+			previous[next] = current
+			heap.Push(open, &queuedNode{node: next, priority: c + b.GreatCircleDistance(to)})
+		}
+	}
+
+	return nil
+}
+
+// A grid node waiting in the A* open set.
+type queuedNode struct {
+	node     gridNode
+	priority float64
+}
+
+// A priority queue of grid nodes, implementing heap.Interface.
+type nodeQueue []*queuedNode
+
+func (q nodeQueue) Len() int            { return len(q) }
+func (q nodeQueue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q nodeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *nodeQueue) Push(x interface{}) { *q = append(*q, x.(*queuedNode)) }
+func (q *nodeQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
+
+// The largest angle (in degrees, seen from the center) between the waypoints
+// Point.RouteAvoiding places along the edge of an exclusion zone.
+const avoidArcStepDeg = 10.0
+
+// Returns a route from the current Point to the passed in Point, as waypoints joined by great circle legs,
+// which stays outside of the circular zone around the passed in obstacle of the passed in radius (in sea miles).
+// If the direct great circle does not enter the zone, the route is just the two points.
+// Otherwise it follows the tangents to the zone and its edge around the shorter side.
+// Returns nil if either point lies inside of the zone, since there is no way around it.
+func (p *Point) RouteAvoiding(p2 *Point, obstacle *Point, radius float64) []*Point {
+	if obstacle.GreatCircleDistance(p) <= radius || obstacle.GreatCircleDistance(p2) <= radius {
+		return nil
+	}
+	if distanceToSegment(p, p2, obstacle) >= radius {
+		return []*Point{p, p2}
+	}
+
+	// The angles (in radians) at the obstacle between each point and its tangent points,
+	// from the right-angled spherical triangle formed by the obstacle, the point and the tangent point
+	r := radius / EARTHRADIUS
+	tangentAngle := func(q *Point) float64 {
+		return math.Acos(math.Tan(r)/math.Tan(obstacle.GreatCircleDistance(q)/EARTHRADIUS)) * 180.0 / math.Pi
+	}
+	bearing1, angle1 := obstacle.BearingTo(p), tangentAngle(p)
+	bearing2, angle2 := obstacle.BearingTo(p2), tangentAngle(p2)
+
+	// The sweeps around the zone clockwise and counter clockwise, between the tangent points
+	clockwise := math.Mod((bearing2-angle2)-(bearing1+angle1)+720, 360)
+	counterClockwise := math.Mod((bearing1-angle1)-(bearing2+angle2)+720, 360)
+	start, sweep := bearing1+angle1, clockwise
+	if counterClockwise < clockwise {
+		start, sweep = bearing1-angle1, -counterClockwise
+	}
+
+	// Widen the zone so the legs between the waypoints on its edge stay outside of it
+	steps := int(math.Ceil(math.Abs(sweep)/avoidArcStepDeg)) + 1
+	step := sweep / float64(steps)
+	edge := math.Atan(math.Tan(r)/math.Cos(step/2*math.Pi/180.0)) * EARTHRADIUS
+
+	route := []*Point{p}
+	for i := 0; i <= steps; i++ {
+		route = append(route, obstacle.PointAtDistanceAndBearing(edge, start+step*float64(i)))
+	}
+	return append(route, p2)
+}
@@ -0,0 +1,131 @@
+package geo
+
+import (
+	"testing"
+)
+
+// Asserts that no leg of the passed in route touches or enters the exclusion.
+func assertRouteAvoids(t *testing.T, route []*Point, exclusion *Polygon) {
+	for i := 1; i < len(route); i++ {
+		if exclusion.intersectsSegment(route[i-1], route[i]) {
+			t.Errorf("Expected the route to avoid the exclusion, but %v to %v intersects it", route[i-1], route[i])
+		}
+		if exclusion.Contains(route[i]) {
+			t.Errorf("Expected the route to avoid the exclusion, but %v is inside of it", route[i])
+		}
+	}
+}
+
+// Ensures that the direct great circle is returned when nothing is in the way.
+func TestRouteAvoidingDirect(t *testing.T) {
+	from := NewPoint(0, -5)
+	to := NewPoint(0, 5)
+	elsewhere := NewPolygon([]*Point{NewPoint(20, 20), NewPoint(20, 22), NewPoint(22, 22), NewPoint(22, 20)})
+
+	route, err := RouteAvoiding(from, to, []*Polygon{elsewhere}, RouteOptions{})
+	if err != nil {
+		t.Fatalf("Expected err to be nil, but got %v instead.", err)
+	}
+
+	direct := from.GreatCircleSegments(to, 1)[0]
+	if len(route) != len(direct) {
+		t.Fatalf("Expected the direct route of %d points, but got %d points instead", len(direct), len(route))
+	}
+	for i := range route {
+		if route[i].GreatCircleDistance(direct[i]) > 0.000001 {
+			t.Errorf("Expected point %d of the route to be %v, but got %v instead", i, direct[i], route[i])
+		}
+	}
+}
+
+// Ensures that a route is found around a square straddling the direct path.
+func TestRouteAvoidingSquare(t *testing.T) {
+	from := NewPoint(0, -5)
+	to := NewPoint(0, 5)
+	square := NewPolygon([]*Point{NewPoint(-1, -1), NewPoint(-1, 1), NewPoint(1, 1), NewPoint(1, -1)})
+
+	route, err := RouteAvoiding(from, to, []*Polygon{square}, RouteOptions{ResolutionDeg: 0.5})
+	if err != nil {
+		t.Fatalf("Expected err to be nil, but got %v instead.", err)
+	}
+
+	if route[0].GreatCircleDistance(from) > 0.000001 {
+		t.Errorf("Expected the route to start at %v, but got %v instead", from, route[0])
+	}
+	if route[len(route)-1].GreatCircleDistance(to) > 0.000001 {
+		t.Errorf("Expected the route to end at %v, but got %v instead", to, route[len(route)-1])
+	}
+
+	assertRouteAvoids(t, route, square)
+}
+
+// Ensures that an error is returned when the destination cannot be reached.
+func TestRouteAvoidingNoRoute(t *testing.T) {
+	from := NewPoint(0, -5)
+	to := NewPoint(0, 5)
+
+	// A wall reaching beyond the search bounds
+	wall := NewPolygon([]*Point{NewPoint(-30, -1), NewPoint(-30, 1), NewPoint(30, 1), NewPoint(30, -1)})
+	if _, err := RouteAvoiding(from, to, []*Polygon{wall}, RouteOptions{MarginDeg: 5}); err == nil {
+		t.Error("Expected an error when no route exists within the search bounds")
+	}
+
+	if _, err := RouteAvoiding(NewPoint(25, 0), to, []*Polygon{wall}, RouteOptions{}); err == nil {
+		t.Error("Expected an error when routing from inside of an exclusion")
+	}
+}
+
+// Ensures that a route around a circular zone clipped by the direct great circle stays outside of it.
+func TestPointRouteAvoiding(t *testing.T) {
+	from := NewPoint(0, 0)
+	to := NewPoint(0, 10)
+	// Slightly off the direct path, which passes within 60 sea miles of it
+	obstacle := NewPoint(1, 5)
+	radius := 120.0
+
+	route := from.RouteAvoiding(to, obstacle, radius)
+	if len(route) < 4 || route[0] != from || route[len(route)-1] != to {
+		t.Fatalf("Expected a detour from %v to %v, got %v", from, to, route)
+	}
+
+	length := 0.0
+	for i := 1; i < len(route); i++ {
+		length += route[i-1].GreatCircleDistance(route[i])
+		for f := 0.0; f <= 1.0; f += 0.01 {
+			if d := obstacle.GreatCircleDistance(route[i-1].IntermediatePointTo(route[i], f)); d < radius-1e-6 {
+				t.Fatalf("Leg %d enters the zone, at %f sea miles from the obstacle", i, d)
+			}
+		}
+	}
+
+	// The detour passes south of the obstacle, the shorter way around
+	for _, p := range route {
+		if p.lat > 1 {
+			t.Errorf("Expected the detour to pass south, got %v", p)
+		}
+	}
+	if direct := from.GreatCircleDistance(to); length > direct*1.2 {
+		t.Errorf("Expected a short detour, got %f for %f", length, direct)
+	}
+}
+
+// Ensures that the direct route is returned when it is clear, and nil when there is no way around.
+func TestPointRouteAvoidingClearAndBlocked(t *testing.T) {
+	from := NewPoint(0, 0)
+	to := NewPoint(0, 10)
+
+	route := from.RouteAvoiding(to, NewPoint(5, 5), 120)
+	if len(route) != 2 || route[0] != from || route[1] != to {
+		t.Errorf("Expected the direct route, got %v", route)
+	}
+
+	// The zone lies beyond the end of the route
+	route = from.RouteAvoiding(to, NewPoint(0, 13), 120)
+	if len(route) != 2 {
+		t.Errorf("Expected the direct route, got %v", route)
+	}
+
+	if route := from.RouteAvoiding(to, NewPoint(0, 9), 120); route != nil {
+		t.Errorf("Expected no route into the zone, got %v", route)
+	}
+}
@@ -0,0 +1,101 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// The largest number of entries per node of an RTree.
+const rtreeNodeSize = 16
+
+// An RTree indexes Polygons by their bounding boxes, to quickly find
+// the candidates which may contain a point.  It is built once from all of its
+// Polygons and can not be changed afterwards.
+type RTree struct {
+	root *rtreeNode
+}
+
+// A node of an RTree, holding either child nodes or Polygons.
+type rtreeNode struct {
+	minLat, minLng, maxLat, maxLng float64
+
+	children []*rtreeNode
+	polygon  *Polygon
+}
+
+// Builds an RTree of the passed in Polygons, using the Sort-Tile-Recursive bulk loading algorithm.
+// The bounding boxes of the Polygons are taken in the lat/lng plane, so Polygons must not cross the antimeridian.
+func BuildRTree(polygons []*Polygon) *RTree {
+	nodes := make([]*rtreeNode, 0, len(polygons))
+	for _, polygon := range polygons {
+		if len(polygon.points) == 0 {
+			continue
+		}
+		node := &rtreeNode{polygon: polygon}
+		node.minLat, node.minLng, node.maxLat, node.maxLng = polygon.bounds()
+		nodes = append(nodes, node)
+	}
+	if len(nodes) == 0 {
+		return &RTree{}
+	}
+
+	for len(nodes) > 1 {
+		nodes = packRTreeLevel(nodes)
+	}
+	return &RTree{root: nodes[0]}
+}
+
+// Groups the passed in nodes into parent nodes, by sorting them into vertical
+// slices by longitude and then into runs of rtreeNodeSize by latitude.
+func packRTreeLevel(nodes []*rtreeNode) []*rtreeNode {
+	parents := int(math.Ceil(float64(len(nodes)) / rtreeNodeSize))
+	sliceSize := int(math.Ceil(math.Sqrt(float64(parents)))) * rtreeNodeSize
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].minLng+nodes[i].maxLng < nodes[j].minLng+nodes[j].maxLng })
+
+	packed := make([]*rtreeNode, 0, parents)
+	for start := 0; start < len(nodes); start += sliceSize {
+		slice := nodes[start:int(math.Min(float64(start+sliceSize), float64(len(nodes))))]
+		sort.Slice(slice, func(i, j int) bool { return slice[i].minLat+slice[i].maxLat < slice[j].minLat+slice[j].maxLat })
+
+		for i := 0; i < len(slice); i += rtreeNodeSize {
+			children := slice[i:int(math.Min(float64(i+rtreeNodeSize), float64(len(slice))))]
+			parent := &rtreeNode{
+				children: append([]*rtreeNode{}, children...),
+				minLat:   math.Inf(1),
+				minLng:   math.Inf(1),
+				maxLat:   math.Inf(-1),
+				maxLng:   math.Inf(-1),
+			}
+			for _, child := range children {
+				parent.minLat, parent.maxLat = math.Min(parent.minLat, child.minLat), math.Max(parent.maxLat, child.maxLat)
+				parent.minLng, parent.maxLng = math.Min(parent.minLng, child.minLng), math.Max(parent.maxLng, child.maxLng)
+			}
+			packed = append(packed, parent)
+		}
+	}
+	return packed
+}
+
+// Returns the Polygons whose bounding boxes contain the passed in point.
+// These are candidates only, use Polygon.Contains to find the ones actually containing the point.
+func (t *RTree) Search(p *Point) []*Polygon {
+	candidates := []*Polygon{}
+	if t.root != nil {
+		t.root.search(p, &candidates)
+	}
+	return candidates
+}
+
+func (n *rtreeNode) search(p *Point, candidates *[]*Polygon) {
+	if p.lat < n.minLat || p.lat > n.maxLat || p.lng < n.minLng || p.lng > n.maxLng {
+		return
+	}
+	if n.polygon != nil {
+		*candidates = append(*candidates, n.polygon)
+		return
+	}
+	for _, child := range n.children {
+		child.search(p, candidates)
+	}
+}
@@ -0,0 +1,77 @@
+package geo
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// Returns the passed in number of random small squares scattered over the globe.
+func randomSquares(n int, rng *rand.Rand) []*Polygon {
+	polygons := make([]*Polygon, n)
+	for i := range polygons {
+		lat, lng := rng.Float64()*160-80, rng.Float64()*340-170
+		size := rng.Float64()*5 + 0.1
+		polygons[i] = NewPolygon([]*Point{
+			NewPoint(lat, lng), NewPoint(lat, lng+size), NewPoint(lat+size, lng+size), NewPoint(lat+size, lng),
+		})
+	}
+	return polygons
+}
+
+// Ensures that the candidates found by the RTree include every Polygon containing the point,
+// and only Polygons whose bounding boxes contain it.
+func TestRTreeSearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	polygons := randomSquares(1000, rng)
+	tree := BuildRTree(polygons)
+
+	for i := 0; i < 1000; i++ {
+		p := NewPoint(rng.Float64()*170-85, rng.Float64()*350-175)
+
+		candidates := map[*Polygon]bool{}
+		for _, candidate := range tree.Search(p) {
+			candidates[candidate] = true
+			minLat, minLng, maxLat, maxLng := candidate.bounds()
+			if p.lat < minLat || p.lat > maxLat || p.lng < minLng || p.lng > maxLng {
+				t.Fatal("Unnacceptable result.", fmt.Sprintf("%v is outside of the bounding box of candidate %v", p, candidate.Points()))
+			}
+		}
+
+		for _, polygon := range polygons {
+			if polygon.Contains(p) && !candidates[polygon] {
+				t.Fatal("Unnacceptable result.", fmt.Sprintf("%v is in %v, which is not a candidate", p, polygon.Points()))
+			}
+		}
+	}
+
+	if candidates := BuildRTree(nil).Search(NewPoint(0, 0)); len(candidates) != 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected no candidates in an empty tree, got %v", candidates))
+	}
+}
+
+func BenchmarkRTreeSearch(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	tree := BuildRTree(randomSquares(10000, rng))
+	p := NewPoint(10, 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree.Search(p)
+	}
+}
+
+func BenchmarkLinearBoundsScan(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	polygons := randomSquares(10000, rng)
+	p := NewPoint(10, 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		candidates := []*Polygon{}
+		for _, polygon := range polygons {
+			minLat, minLng, maxLat, maxLng := polygon.bounds()
+			if p.lat >= minLat && p.lat <= maxLat && p.lng >= minLng && p.lng <= maxLng {
+				candidates = append(candidates, polygon)
+			}
+		}
+	}
+}
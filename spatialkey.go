@@ -0,0 +1,188 @@
+package geo
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// The number of bits per coordinate of the keys returned by SpatialKey.
+const spatialKeyBits = 32
+
+// The largest number of ranges SpatialKeyRange refines a box into, before merging adjacent ranges.
+const spatialKeyMaxRanges = 64
+
+// Returns a key for the current Point which sorts byte-wise in Z-order, so that nearby points
+// tend to have nearby keys.  The latitude and longitude are quantized to 32 bits each and
+// interleaved, starting with the most significant longitude bit, and stored big endian,
+// so keys are the same on every architecture.  The cells of a key are about 1 cm in size.
+func (p *Point) SpatialKey() [8]byte {
+	var key [8]byte
+	binary.BigEndian.PutUint64(key[:], p.spatialKey(spatialKeyBits))
+	return key
+}
+
+// Same as SpatialKey, but quantizes the coordinates to the passed in number of bits, which has
+// to be a multiple of 4 between 4 and 32.  The returned key is bits/4 bytes long, and is a prefix of
+// the keys of all points within the same cell at a higher number of bits.
+func (p *Point) SpatialKeyWithBits(bits int) ([]byte, error) {
+	if bits < 4 || bits > spatialKeyBits || bits%4 != 0 {
+		return nil, errors.New("Invalid number of bits: " + strconv.Itoa(bits))
+	}
+
+	var key [8]byte
+	binary.BigEndian.PutUint64(key[:], p.spatialKey(bits)<<uint(64-2*bits))
+	return key[:bits/4], nil
+}
+
+// Returns the center of the cell of the passed in key, as returned by SpatialKey or SpatialKeyWithBits.
+// The number of bits per coordinate is derived from the length of the key.
+func PointFromSpatialKey(k []byte) (*Point, error) {
+	if len(k) == 0 || len(k) > 8 {
+		return nil, errors.New("Invalid spatial key length: " + strconv.Itoa(len(k)))
+	}
+
+	var key [8]byte
+	copy(key[:], k)
+	bits := uint(4 * len(k))
+	lngIndex, latIndex := deinterleave(binary.BigEndian.Uint64(key[:]) >> (64 - 2*bits))
+
+	cells := math.Ldexp(1, int(bits))
+	return NewPoint(
+		(float64(latIndex)+0.5)/cells*180.0-90.0,
+		(float64(lngIndex)+0.5)/cells*360.0-180.0,
+	), nil
+}
+
+// Returns the interleaved cell indices of the current Point at the passed in number of bits.
+func (p *Point) spatialKey(bits int) uint64 {
+	return interleave(quantize(p.lng+180.0, 360.0, bits), quantize(p.lat+90.0, 180.0, bits))
+}
+
+// Returns the index of the cell containing the passed in offset into a span divided into 2^bits cells.
+func quantize(offset, span float64, bits int) uint32 {
+	cells := math.Ldexp(1, bits)
+	index := math.Floor(offset / span * cells)
+	return uint32(math.Max(0, math.Min(index, cells-1)))
+}
+
+// Interleaves the bits of the passed in indices, with the bits of a in the more significant positions.
+func interleave(a, b uint32) uint64 {
+	return spread(a)<<1 | spread(b)
+}
+
+// Splits the passed in key into the indices it was interleaved from.
+func deinterleave(key uint64) (a, b uint32) {
+	return compact(key >> 1), compact(key)
+}
+
+// Spreads the bits of x out to every other bit.
+func spread(x uint32) uint64 {
+	v := uint64(x)
+	v = (v | v<<16) & 0x0000FFFF0000FFFF
+	v = (v | v<<8) & 0x00FF00FF00FF00FF
+	v = (v | v<<4) & 0x0F0F0F0F0F0F0F0F
+	v = (v | v<<2) & 0x3333333333333333
+	v = (v | v<<1) & 0x5555555555555555
+	return v
+}
+
+// Collects every other bit of v, the inverse of spread.
+func compact(v uint64) uint32 {
+	v &= 0x5555555555555555
+	v = (v | v>>1) & 0x3333333333333333
+	v = (v | v>>2) & 0x0F0F0F0F0F0F0F0F
+	v = (v | v>>4) & 0x00FF00FF00FF00FF
+	v = (v | v>>8) & 0x0000FFFF0000FFFF
+	v = (v | v>>16) & 0x00000000FFFFFFFF
+	return uint32(v)
+}
+
+// A cell of the quadtree spanned by spatial keys, at a number of bits per coordinate.
+type spatialKeyCell struct {
+	lng, lat uint32
+	bits     uint
+}
+
+// Returns key ranges, with inclusive bounds, covering the box spanned by the passed in south-west and
+// north-east corners, as keys returned by SpatialKey.  The ranges are sorted and do not overlap.
+// To keep the number of ranges small, they also cover some keys close to, but outside of the box,
+// so the points found by scanning them need to be checked against the box.
+// If the north-east corner lies west of the south-west corner, the box is considered to cross the antimeridian.
+func SpatialKeyRange(sw, ne *Point) (lo, hi [][8]byte) {
+	latLo := quantize(sw.lat+90.0, 180.0, spatialKeyBits)
+	latHi := quantize(ne.lat+90.0, 180.0, spatialKeyBits)
+	lngLo := quantize(sw.lng+180.0, 360.0, spatialKeyBits)
+	lngHi := quantize(ne.lng+180.0, 360.0, spatialKeyBits)
+
+	var ranges [][2]uint64
+	if lngLo <= lngHi {
+		ranges = spatialKeyRanges(latLo, latHi, lngLo, lngHi, spatialKeyMaxRanges)
+	} else {
+		ranges = append(spatialKeyRanges(latLo, latHi, lngLo, math.MaxUint32, spatialKeyMaxRanges/2),
+			spatialKeyRanges(latLo, latHi, 0, lngHi, spatialKeyMaxRanges/2)...)
+	}
+
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && merged[n-1][1] != math.MaxUint64 && merged[n-1][1]+1 >= r[0] {
+			if r[1] > merged[n-1][1] {
+				merged[n-1][1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+
+	for _, r := range merged {
+		var l, h [8]byte
+		binary.BigEndian.PutUint64(l[:], r[0])
+		binary.BigEndian.PutUint64(h[:], r[1])
+		lo, hi = append(lo, l), append(hi, h)
+	}
+	return lo, hi
+}
+
+// Refines the quadtree level by level into cells covering the passed in inclusive index ranges,
+// until the cells are exact or refining further would exceed the passed in number of ranges.
+func spatialKeyRanges(latLo, latHi, lngLo, lngHi uint32, maxRanges int) [][2]uint64 {
+	var full []spatialKeyCell
+	partial := []spatialKeyCell{{}}
+
+	for bits := uint(1); bits <= spatialKeyBits && len(partial) > 0; bits++ {
+		var nextFull, nextPartial []spatialKeyCell
+		for _, cell := range partial {
+			for i := uint32(0); i < 4; i++ {
+				child := spatialKeyCell{lng: cell.lng<<1 | i>>1, lat: cell.lat<<1 | i&1, bits: bits}
+				shift := spatialKeyBits - bits
+				childLngLo, childLngHi := child.lng<<shift, child.lng<<shift|(1<<shift-1)
+				childLatLo, childLatHi := child.lat<<shift, child.lat<<shift|(1<<shift-1)
+
+				switch {
+				case childLngHi < lngLo || childLngLo > lngHi || childLatHi < latLo || childLatLo > latHi:
+				case childLngLo >= lngLo && childLngHi <= lngHi && childLatLo >= latLo && childLatHi <= latHi:
+					nextFull = append(nextFull, child)
+				default:
+					nextPartial = append(nextPartial, child)
+				}
+			}
+		}
+
+		if len(full)+len(nextFull)+len(nextPartial) > maxRanges {
+			break
+		}
+		full = append(full, nextFull...)
+		partial = nextPartial
+	}
+
+	ranges := make([][2]uint64, 0, len(full)+len(partial))
+	for _, cell := range append(full, partial...) {
+		shift := 2 * (spatialKeyBits - cell.bits)
+		key := interleave(cell.lng, cell.lat) << shift
+		ranges = append(ranges, [2]uint64{key, key | (1<<shift - 1)})
+	}
+	return ranges
+}
@@ -0,0 +1,140 @@
+package geo
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+)
+
+// Ensures that keys decode to the center of their cell, at every number of bits.
+func TestSpatialKeyRoundTrip(t *testing.T) {
+	for _, p := range []*Point{NewPoint(47.6062, -122.3321), NewPoint(-33.8688, 151.2093), NewPoint(90, 180), NewPoint(-90, -180)} {
+		key := p.SpatialKey()
+		center, err := PointFromSpatialKey(key[:])
+		if err != nil {
+			t.Fatal("Unexpected error:", err)
+		}
+		if math.Abs(center.lat-p.lat) > 1e-7 || math.Abs(center.lng-p.lng) > 1e-7 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("%v decoded to %v", p, center))
+		}
+
+		for bits := 4; bits <= 32; bits += 4 {
+			short, err := p.SpatialKeyWithBits(bits)
+			if err != nil {
+				t.Fatal("Unexpected error:", err)
+			}
+			if !bytes.HasPrefix(key[:], short) {
+				t.Error("Unnacceptable result.", fmt.Sprintf("%x is not a prefix of %x", short, key))
+			}
+			center, _ := PointFromSpatialKey(short)
+			if math.Abs(center.lat-p.lat) > 90/math.Ldexp(1, bits) || math.Abs(center.lng-p.lng) > 180/math.Ldexp(1, bits) {
+				t.Error("Unnacceptable result.", fmt.Sprintf("%v decoded to %v at %d bits", p, center, bits))
+			}
+		}
+	}
+
+	if _, err := NewPoint(0, 0).SpatialKeyWithBits(6); err == nil {
+		t.Error("Expected an error for 6 bits")
+	}
+	if _, err := PointFromSpatialKey(nil); err == nil {
+		t.Error("Expected an error for an empty key")
+	}
+}
+
+// A key/value store of points sorted by their spatial keys.
+type spatialKeyStore []struct {
+	key   [8]byte
+	point *Point
+}
+
+func newSpatialKeyStore(points []*Point) spatialKeyStore {
+	store := make(spatialKeyStore, len(points))
+	for i, p := range points {
+		store[i].key, store[i].point = p.SpatialKey(), p
+	}
+	sort.Slice(store, func(i, j int) bool { return bytes.Compare(store[i].key[:], store[j].key[:]) < 0 })
+	return store
+}
+
+// Returns the points with keys within the passed in inclusive ranges.
+func (s spatialKeyStore) scan(lo, hi [][8]byte) []*Point {
+	var points []*Point
+	for i := range lo {
+		start := sort.Search(len(s), func(j int) bool { return bytes.Compare(s[j].key[:], lo[i][:]) >= 0 })
+		for j := start; j < len(s) && bytes.Compare(s[j].key[:], hi[i][:]) <= 0; j++ {
+			points = append(points, s[j].point)
+		}
+	}
+	return points
+}
+
+// Ensures that nearby points are stored next to each other.
+func TestSpatialKeyOrder(t *testing.T) {
+	var points []*Point
+	for i := 0; i < 5; i++ {
+		d := float64(i) * 1e-4
+		points = append(points, NewPoint(47.6062+d, -122.3321+d), NewPoint(-33.8688-d, 151.2093+d), NewPoint(35.6762+d, 139.6503-d))
+	}
+
+	store := newSpatialKeyStore(points)
+	for i := 1; i < len(store); i++ {
+		if store[i].point.GreatCircleDistance(store[i-1].point) > 1 && i%5 != 0 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected the clusters to be contiguous, got %v next to %v", store[i-1].point, store[i].point))
+		}
+	}
+}
+
+// Ensures that scanning the ranges of a box finds all of the points in it, and few others.
+func TestSpatialKeyRange(t *testing.T) {
+	points := NewPointGrid(NewPoint(-60, -180), NewPoint(60, 179.5), 0.5, 0.5).Points()
+	store := newSpatialKeyStore(points)
+
+	boxes := [][2]*Point{
+		{NewPoint(10, 20), NewPoint(12.5, 23)},
+		{NewPoint(-33.3, 150.1), NewPoint(-31.1, 153.9)},
+		// Across the antimeridian
+		{NewPoint(-5, 178), NewPoint(5, -178)},
+	}
+
+	for _, box := range boxes {
+		sw, ne := box[0], box[1]
+		inBox := func(p *Point) bool {
+			if p.lat < sw.lat || p.lat > ne.lat {
+				return false
+			}
+			if sw.lng <= ne.lng {
+				return p.lng >= sw.lng && p.lng <= ne.lng
+			}
+			return p.lng >= sw.lng || p.lng <= ne.lng
+		}
+
+		lo, hi := SpatialKeyRange(sw, ne)
+		if len(lo) == 0 || len(lo) != len(hi) || len(lo) > spatialKeyMaxRanges {
+			t.Fatal("Unnacceptable result.", fmt.Sprintf("Got %d and %d ranges", len(lo), len(hi)))
+		}
+		for i := range lo {
+			if bytes.Compare(lo[i][:], hi[i][:]) > 0 || (i > 0 && bytes.Compare(hi[i-1][:], lo[i][:]) >= 0) {
+				t.Error("Unnacceptable result.", fmt.Sprintf("Range %d is not sorted: %x - %x", i, lo[i], hi[i]))
+			}
+		}
+
+		scanned := store.scan(lo, hi)
+		found := 0
+		for _, p := range scanned {
+			if inBox(p) {
+				found++
+			}
+		}
+		expected := 0
+		for _, p := range points {
+			if inBox(p) {
+				expected++
+			}
+		}
+		if found != expected || len(scanned) > 4*expected {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Scanned %d points for %v - %v, %d in the box, expected %d", len(scanned), sw, ne, found, expected))
+		}
+	}
+}
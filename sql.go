@@ -0,0 +1,111 @@
+package geo
+
+import (
+	"math"
+	"strconv"
+)
+
+// A Dialect is a flavour of SQL the expressions of HaversineSQL, BearingSQL and WithinRadiusWhere are written in.
+type Dialect int
+
+const (
+	// PostgreSQL, with $1 style placeholders
+	Postgres Dialect = iota
+	// MySQL, with ? placeholders
+	MySQL
+	// SQLite with its math functions enabled, with ? placeholders.
+	// SQLite has no LEAST, so instead of the haversine formula its distances use the spherical law of cosines,
+	// clamped with the MIN and MAX it does have.  The cosines of close points are rounded to 1, which limits
+	// the accuracy of very short distances to about a tenth of a meter.
+	SQLite
+)
+
+// The factor converting degrees to radians within SQL expressions.
+var sqlRadians = strconv.FormatFloat(math.Pi/180.0, 'g', -1, 64)
+
+// Collects the arguments of an SQL expression as it is written.
+type sqlBuilder struct {
+	dialect Dialect
+	args    []interface{}
+}
+
+// Returns the placeholder for the passed in argument, which is appended to the arguments.
+func (b *sqlBuilder) param(value float64) string {
+	b.args = append(b.args, value)
+	if b.dialect == Postgres {
+		return "$" + strconv.Itoa(len(b.args))
+	}
+	return "?"
+}
+
+// Returns the expression converting the passed in column from degrees to radians.
+func (b *sqlBuilder) radians(col string) string {
+	return "(" + col + " * " + sqlRadians + ")"
+}
+
+// Returns the expression of the great circle distance (in sea miles) from the origin to the row.
+func (b *sqlBuilder) haversine(latCol, lngCol string, origin *Point) string {
+	lat1 := origin.lat * math.Pi / 180.0
+	lng1 := origin.lng * math.Pi / 180.0
+	radius := strconv.FormatFloat(EARTHRADIUS, 'g', -1, 64)
+	lat, lng := b.radians(latCol), b.radians(lngCol)
+
+	if b.dialect == SQLite {
+		// The spherical law of cosines, clamped against rounding errors pushing ACOS out of its domain
+		return radius + " * ACOS(MAX(-1, MIN(1, " + b.param(math.Sin(lat1)) + " * SIN(" + lat + ") + " +
+			b.param(math.Cos(lat1)) + " * COS(" + lat + ") * COS(" + lng + " - " + b.param(lng1) + "))))"
+	}
+	return "2 * " + radius + " * ASIN(LEAST(1, SQRT(POWER(SIN((" + lat + " - " + b.param(lat1) + ") / 2), 2) + " +
+		b.param(math.Cos(lat1)) + " * COS(" + lat + ") * POWER(SIN((" + lng + " - " + b.param(lng1) + ") / 2), 2))))"
+}
+
+// Returns an SQL expression of the passed in dialect calculating the great circle distance (in sea miles)
+// from the passed in origin to the point stored in the passed in latitude and longitude columns (in degrees),
+// along with the arguments of its placeholders.  The coordinates of the origin are only ever passed as arguments,
+// while the column names are inserted as they are, so they must not come from untrusted input.
+func HaversineSQL(latCol, lngCol string, origin *Point, dialect Dialect) (expr string, args []interface{}) {
+	b := &sqlBuilder{dialect: dialect}
+	return b.haversine(latCol, lngCol, origin), b.args
+}
+
+// Returns an SQL expression of the passed in dialect calculating the initial bearing (in degrees) from the passed in
+// origin to the point stored in the passed in latitude and longitude columns, along with the arguments of its placeholders.
+// Like BearingTo, bearings are measured clockwise from north, but the expression may return 360 instead of 0.
+// The column names are inserted as they are, so they must not come from untrusted input.
+func BearingSQL(latCol, lngCol string, origin *Point, dialect Dialect) (expr string, args []interface{}) {
+	b := &sqlBuilder{dialect: dialect}
+	lat1 := origin.lat * math.Pi / 180.0
+	lng1 := origin.lng * math.Pi / 180.0
+	lat, lng := b.radians(latCol), b.radians(lngCol)
+	degrees := strconv.FormatFloat(180.0/math.Pi, 'g', -1, 64)
+
+	// ATAN2 of the negated terms is off by 180 degrees, which maps its range onto [0, 360]
+	// without having to take the remainder of a floating point number.
+	y := "SIN(" + b.param(lng1) + " - " + lng + ") * COS(" + lat + ")"
+	x := b.param(math.Sin(lat1)) + " * COS(" + lat + ") * COS(" + lng + " - " + b.param(lng1) + ") - " +
+		b.param(math.Cos(lat1)) + " * SIN(" + lat + ")"
+	return "(ATAN2(" + y + ", " + x + ") * " + degrees + " + 180)", b.args
+}
+
+// Returns an SQL condition of the passed in dialect matching the rows whose point, stored in the passed in latitude
+// and longitude columns, is within the passed in radius (in sea miles) of the passed in origin, along with the arguments
+// of its placeholders.  The exact distance is preceded by a comparison against the bounding box of the circle,
+// allowing the database to use an index on the columns.  The column names are inserted as they are,
+// so they must not come from untrusted input.
+func WithinRadiusWhere(latCol, lngCol string, origin *Point, radius float64, dialect Dialect) (where string, args []interface{}) {
+	b := &sqlBuilder{dialect: dialect}
+	minLat, minLng, maxLat, maxLng := circleBounds(origin, radius)
+
+	where = latCol + " BETWEEN " + b.param(math.Max(minLat, -90)) + " AND " + b.param(math.Min(maxLat, 90))
+	switch {
+	case minLng <= -180 && maxLng >= 180:
+	case minLng < -180:
+		where += " AND (" + lngCol + " >= " + b.param(minLng+360) + " OR " + lngCol + " <= " + b.param(maxLng) + ")"
+	case maxLng > 180:
+		where += " AND (" + lngCol + " >= " + b.param(minLng) + " OR " + lngCol + " <= " + b.param(maxLng-360) + ")"
+	default:
+		where += " AND " + lngCol + " BETWEEN " + b.param(minLng) + " AND " + b.param(maxLng)
+	}
+	where += " AND " + b.haversine(latCol, lngCol, origin) + " <= " + b.param(radius)
+	return where, b.args
+}
@@ -0,0 +1,121 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// Expands the shorthands {lat} and {lng} for the columns converted to radians.
+func expandSQL(s string) string {
+	return strings.NewReplacer("{lat}", "(lat * 0.017453292519943295)", "{lng}", "(lng * 0.017453292519943295)").Replace(s)
+}
+
+// Ensures that the distance expressions are written in each dialect, with the origin passed as arguments.
+func TestHaversineSQL(t *testing.T) {
+	origin := NewPoint(30, -90)
+	tests := []struct {
+		dialect  Dialect
+		expected string
+		args     []interface{}
+	}{
+		{Postgres, "2 * 3440.065334773 * ASIN(LEAST(1, SQRT(POWER(SIN(({lat} - $1) / 2), 2) + $2 * COS({lat}) * POWER(SIN(({lng} - $3) / 2), 2))))",
+			[]interface{}{math.Pi / 6, math.Cos(math.Pi / 6), -math.Pi / 2}},
+		{MySQL, "2 * 3440.065334773 * ASIN(LEAST(1, SQRT(POWER(SIN(({lat} - ?) / 2), 2) + ? * COS({lat}) * POWER(SIN(({lng} - ?) / 2), 2))))",
+			[]interface{}{math.Pi / 6, math.Cos(math.Pi / 6), -math.Pi / 2}},
+		{SQLite, "3440.065334773 * ACOS(MAX(-1, MIN(1, ? * SIN({lat}) + ? * COS({lat}) * COS({lng} - ?))))",
+			[]interface{}{math.Sin(math.Pi / 6), math.Cos(math.Pi / 6), -math.Pi / 2}},
+	}
+
+	for _, test := range tests {
+		expr, args := HaversineSQL("lat", "lng", origin, test.dialect)
+		if expr != expandSQL(test.expected) {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %s, but got %s instead", expandSQL(test.expected), expr))
+		}
+		if !argsEqual(args, test.args) {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected the arguments %v, but got %v instead", test.args, args))
+		}
+	}
+}
+
+// Returns whether or not the passed in arguments are equal, allowing for rounding errors.
+func argsEqual(args, expected []interface{}) bool {
+	if len(args) != len(expected) {
+		return false
+	}
+	for i := range args {
+		if math.Abs(args[i].(float64)-expected[i].(float64)) > 1e-12 {
+			return false
+		}
+	}
+	return true
+}
+
+// Ensures that the bearing expression is written with the origin passed as arguments.
+func TestBearingSQL(t *testing.T) {
+	expr, args := BearingSQL("lat", "lng", NewPoint(30, -90), Postgres)
+	expected := expandSQL("(ATAN2(SIN($1 - {lng}) * COS({lat}), $2 * COS({lat}) * COS({lng} - $3) - $4 * SIN({lat})) * 57.29577951308232 + 180)")
+	if expr != expected {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %s, but got %s instead", expected, expr))
+	}
+	if len(args) != 4 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected 4 arguments, but got %v instead", args))
+	}
+
+	// Evaluates the formula of the expression with its arguments
+	for _, p := range []*Point{NewPoint(40.7486, -73.9864), NewPoint(-33.8, 151.2), NewPoint(10, -120)} {
+		lat, lng := p.lat*math.Pi/180, p.lng*math.Pi/180
+		y := math.Sin(args[0].(float64)-lng) * math.Cos(lat)
+		x := args[1].(float64)*math.Cos(lat)*math.Cos(lng-args[2].(float64)) - args[3].(float64)*math.Sin(lat)
+		if bearing := math.Atan2(y, x)*180/math.Pi + 180; math.Abs(bearing-NewPoint(30, -90).BearingTo(p)) > 1e-9 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected a bearing of %v to %v, but got %v instead", NewPoint(30, -90).BearingTo(p), p, bearing))
+		}
+	}
+
+	if expr, _ := BearingSQL("lat", "lng", NewPoint(30, -90), MySQL); strings.Contains(expr, "$") {
+		t.Error("Expected MySQL placeholders, but got", expr)
+	}
+}
+
+// Ensures that the condition prefilters by the bounding box of the circle, also across the antimeridian and around the poles.
+func TestWithinRadiusWhere(t *testing.T) {
+	where, args := WithinRadiusWhere("lat", "lng", NewPoint(30, -90), 60, SQLite)
+	expected := expandSQL("lat BETWEEN ? AND ? AND lng BETWEEN ? AND ? AND 3440.065334773 * ACOS(MAX(-1, MIN(1, ? * SIN({lat}) + ? * COS({lat}) * COS({lng} - ?)))) <= ?")
+	if where != expected {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %s, but got %s instead", expected, where))
+	}
+	if len(args) != 8 || args[7] != 60.0 || math.Abs(args[0].(float64)-29) > 1e-2 || math.Abs(args[1].(float64)-31) > 1e-2 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the bounds of the circle and its radius, but got %v instead", args))
+	}
+
+	where, args = WithinRadiusWhere("lat", "lng", NewPoint(0, 179.5), 60, Postgres)
+	if !strings.HasPrefix(where, "lat BETWEEN $1 AND $2 AND (lng >= $3 OR lng <= $4) AND ") || !strings.HasSuffix(where, " <= $8") {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a prefilter across the antimeridian, but got %s instead", where))
+	}
+	if args[2].(float64) <= 178 || args[3].(float64) >= -179 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the longitudes to wrap around, but got %v instead", args))
+	}
+
+	where, args = WithinRadiusWhere("lat", "lng", NewPoint(89.5, 0), 60, MySQL)
+	if strings.Contains(where, "lng BETWEEN") || strings.Contains(where, "lng >=") || args[1] != 90.0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected no longitude prefilter around the pole, but got %s %v instead", where, args))
+	}
+}
+
+// Ensures that the formula of the SQLite expression, evaluated with its arguments, matches GreatCircleDistance.
+// Running the expression against an actual SQLite database is deliberately out of scope:
+// the package has no dependencies, and an SQLite driver for database/sql would add one.
+func TestHaversineSQLiteFormula(t *testing.T) {
+	origin := NewPoint(30, -90)
+	_, args := HaversineSQL("lat", "lng", origin, SQLite)
+	sinLat1, cosLat1, lng1 := args[0].(float64), args[1].(float64), args[2].(float64)
+
+	for _, p := range []*Point{NewPoint(30, -90), NewPoint(40.7486, -73.9864), NewPoint(-33.8, 151.2)} {
+		lat, lng := p.lat*math.Pi/180, p.lng*math.Pi/180
+		dist := EARTHRADIUS * math.Acos(math.Max(-1, math.Min(1, sinLat1*math.Sin(lat)+cosLat1*math.Cos(lat)*math.Cos(lng-lng1))))
+		if math.Abs(dist-origin.GreatCircleDistance(p)) > 1e-6 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v for %v, but got %v instead", origin.GreatCircleDistance(p), p, dist))
+		}
+	}
+}
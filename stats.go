@@ -0,0 +1,121 @@
+package geo
+
+import (
+	"errors"
+	"math"
+)
+
+// Below this length the resultant of the position vectors is considered to be zero,
+// which means the points have no well defined centroid (e.g. antipodal pairs).
+const minResultant = 1e-9
+
+// Calculates the geographic centroid of the passed in points by averaging
+// their position vectors on the unit sphere.
+// Returns an error if there are no points or if the points cancel each other out.
+func centroid(points []*Point) (*Point, error) {
+	if len(points) == 0 {
+		return nil, errors.New("Unable to compute the centroid of zero points")
+	}
+
+	var sx, sy, sz float64
+	for _, p := range points {
+		x, y, z := p.toVector()
+		sx, sy, sz = sx+x, sy+y, sz+z
+	}
+
+	if math.Sqrt(sx*sx+sy*sy+sz*sz)/float64(len(points)) < minResultant {
+		return nil, errors.New("Unable to compute the centroid of points that cancel each other out")
+	}
+
+	return pointFromVector(sx, sy, sz), nil
+}
+
+// Calculates the standard distance (the root mean square great circle distance
+// from the centroid) of the passed in points in sea miles, along with the centroid itself.
+// Returns an error for fewer than two points, or when the points are spread
+// so evenly around the globe (e.g. antipodal pairs) that no centroid exists.
+func StandardDistance(points []*Point) (dist float64, center *Point, err error) {
+	if len(points) < 2 {
+		return 0, nil, errors.New("Unable to compute the standard distance of fewer than two points")
+	}
+
+	center, err = centroid(points)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	sum := 0.0
+	for _, p := range points {
+		d := center.GreatCircleDistance(p)
+		sum += d * d
+	}
+
+	return math.Sqrt(sum / float64(len(points))), center, nil
+}
+
+// Calculates the directional dispersion of the passed in points using circular
+// statistics of the bearings from their centroid to each point.
+// Returns the mean bearing in degrees and the mean resultant length,
+// which ranges from 0 (bearings evenly spread) to 1 (all bearings identical).
+// Points coinciding with the centroid have no bearing and are skipped.
+// Returns an error for fewer than two points, for points all coinciding with their centroid,
+// or when the points have no centroid (e.g. antipodal pairs).
+func DirectionalDispersion(points []*Point) (meanBearing float64, resultantLength float64, err error) {
+	if len(points) < 2 {
+		return 0, 0, errors.New("Unable to compute the directional dispersion of fewer than two points")
+	}
+
+	center, err := centroid(points)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	bearings := []float64{}
+	for _, p := range points {
+		if center.GreatCircleDistance(p) == 0 {
+			continue
+		}
+		bearings = append(bearings, center.BearingTo(p))
+	}
+	if len(bearings) == 0 {
+		return 0, 0, errors.New("Unable to compute the directional dispersion of points coinciding with their centroid")
+	}
+
+	meanBearing, resultantLength = circularMean(bearings)
+	return meanBearing, resultantLength, nil
+}
+
+// Calculates the circular mean of the passed in bearings (in degrees)
+// by summing their unit vectors, so that e.g. the mean of 350 and 10 degrees is 0 degrees.
+// Returns a bearing between 0 and 360 degrees.
+// If the bearings cancel each other out, or there are none, there is no mean and 0 is returned.
+func MeanBearing(bearings []float64) float64 {
+	mean, _ := circularMean(bearings)
+	return mean
+}
+
+// Calculates the circular mean (in degrees, between 0 and 360) of the passed in bearings
+// along with their mean resultant length, which ranges from 0 (bearings evenly spread)
+// to 1 (all bearings identical).
+func circularMean(bearings []float64) (mean float64, resultantLength float64) {
+	if len(bearings) == 0 {
+		return 0, 0
+	}
+
+	var sinSum, cosSum float64
+	for _, bearing := range bearings {
+		b := bearing * math.Pi / 180.0
+		sinSum += math.Sin(b)
+		cosSum += math.Cos(b)
+	}
+
+	resultantLength = math.Sqrt(sinSum*sinSum+cosSum*cosSum) / float64(len(bearings))
+	if resultantLength < minResultant {
+		return 0, 0
+	}
+
+	// Tiny negative angles would otherwise round up to 360
+	mean = math.Mod(math.Atan2(sinSum, cosSum)*180.0/math.Pi+360., 360.)
+
+	return mean, resultantLength
+}
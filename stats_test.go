@@ -0,0 +1,138 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Ensures that a tight cluster with a single outlier has a small standard distance
+// and a high resultant length pointing towards the cluster.
+func TestSpreadOfTightCluster(t *testing.T) {
+	origin := NewPoint(47.6, -122.3)
+	points := []*Point{}
+	for i := 0; i < 9; i++ {
+		points = append(points, origin.PointAtDistanceAndBearing(0.001*float64(i), 0))
+	}
+	points = append(points, origin.PointAtDistanceAndBearing(1, 180))
+
+	dist, center, err := StandardDistance(points)
+	if err != nil {
+		t.Errorf("Expected err to be nil, but got %v instead.", err)
+	}
+	if center == nil || dist > 1 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("%f", dist))
+	}
+
+	bearing, length, err := DirectionalDispersion(points)
+	if err != nil {
+		t.Errorf("Expected err to be nil, but got %v instead.", err)
+	}
+	if length < 0.75 {
+		t.Error("Unnacceptable resultant length.", fmt.Sprintf("%f", length))
+	}
+	if bearing > 0.1 && bearing < 359.9 {
+		t.Error("Unnacceptable mean bearing.", fmt.Sprintf("%f", bearing))
+	}
+}
+
+// Ensures that points evenly spread on a ring have a resultant length near zero
+// and a standard distance equal to the radius of the ring.
+func TestSpreadOfRing(t *testing.T) {
+	origin := NewPoint(10, 20)
+	points := []*Point{}
+	for b := 0.0; b < 360; b += 30 {
+		points = append(points, origin.PointAtDistanceAndBearing(50, b))
+	}
+
+	dist, center, err := StandardDistance(points)
+	if err != nil {
+		t.Errorf("Expected err to be nil, but got %v instead.", err)
+	}
+	if dist < 49.9 || dist > 50.1 {
+		t.Error("Unnacceptable standard distance.", fmt.Sprintf("%f", dist))
+	}
+	if center.GreatCircleDistance(origin) > 0.1 {
+		t.Error("Unnacceptable centroid.", fmt.Sprintf("[%f, %f]", center.lat, center.lng))
+	}
+
+	_, length, err := DirectionalDispersion(points)
+	if err != nil {
+		t.Errorf("Expected err to be nil, but got %v instead.", err)
+	}
+	if length > 0.01 {
+		t.Error("Unnacceptable resultant length.", fmt.Sprintf("%f", length))
+	}
+}
+
+// Ensures that degenerate inputs are rejected.
+func TestStandardDistanceErrors(t *testing.T) {
+	if _, _, err := StandardDistance(nil); err == nil {
+		t.Error("Expected an error for zero points")
+	}
+
+	if _, _, err := StandardDistance([]*Point{NewPoint(1, 1)}); err == nil {
+		t.Error("Expected an error for a single point")
+	}
+
+	if _, _, err := StandardDistance([]*Point{NewPoint(0, 0), NewPoint(0, 180)}); err == nil {
+		t.Error("Expected an error for antipodal points")
+	}
+
+	if _, _, err := DirectionalDispersion(nil); err == nil {
+		t.Error("Expected an error for the directional dispersion of zero points")
+	}
+
+	if _, _, err := DirectionalDispersion([]*Point{NewPoint(1, 1)}); err == nil {
+		t.Error("Expected an error for the directional dispersion of a single point")
+	}
+
+	if _, _, err := DirectionalDispersion([]*Point{NewPoint(0, 0), NewPoint(0, 0)}); err == nil {
+		t.Error("Expected an error for the directional dispersion of coinciding points")
+	}
+
+	if _, _, err := DirectionalDispersion([]*Point{NewPoint(0, 0), NewPoint(0, 180)}); err == nil {
+		t.Error("Expected an error for the directional dispersion of antipodal points")
+	}
+}
+
+// Ensures that the mean of bearings clustered around north is north.
+func TestMeanBearingAroundNorth(t *testing.T) {
+	var meantests = []struct {
+		in  []float64
+		out float64
+	}{
+		{[]float64{350, 10}, 0},
+		{[]float64{340, 350, 10, 20}, 0},
+		{[]float64{355, 15}, 5},
+		{[]float64{80, 100}, 90},
+		{[]float64{270}, 270},
+	}
+
+	for _, tt := range meantests {
+		mean := MeanBearing(tt.in)
+		diff := math.Abs(mean - tt.out)
+		if math.Min(diff, 360-diff) > 0.000001 {
+			t.Errorf("Expected the mean bearing of %v to be %f, but got %f instead", tt.in, tt.out, mean)
+		}
+		if mean < 0 || mean >= 360 {
+			t.Errorf("Expected the mean bearing to be in [0, 360), but got %f instead", mean)
+		}
+	}
+}
+
+// Ensures that evenly spread bearings have no mean.
+func TestMeanBearingUniform(t *testing.T) {
+	bearings := []float64{}
+	for b := 0.0; b < 360; b += 45 {
+		bearings = append(bearings, b)
+	}
+
+	if mean := MeanBearing(bearings); mean != 0 {
+		t.Errorf("Expected evenly spread bearings to have no mean, but got %f instead", mean)
+	}
+
+	if mean := MeanBearing(nil); mean != 0 {
+		t.Errorf("Expected no bearings to have no mean, but got %f instead", mean)
+	}
+}
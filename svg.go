@@ -0,0 +1,77 @@
+package geo
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// A Projection maps a Point onto a plane, with x growing towards the east and y towards the north.
+type Projection func(p *Point) (x, y float64)
+
+// Projects the passed in Point onto the plane of its longitude and latitude (in degrees), also known as plate carrée.
+func Equirectangular(p *Point) (x, y float64) {
+	return p.lng, p.lat
+}
+
+// Projects the passed in Point with the spherical Mercator projection used by web maps (in radians).
+// Latitudes are clamped to ±85.05112878, where the projection becomes square.
+func WebMercator(p *Point) (x, y float64) {
+	lat := math.Max(-85.05112878, math.Min(85.05112878, p.lat)) * math.Pi / 180.0
+	return p.lng * math.Pi / 180.0, math.Log(math.Tan(math.Pi/4 + lat/2))
+}
+
+// Returns the SVG path data (the d attribute of a path element) drawing the current Polygon with the passed in
+// Projection, scaled so that the box spanned by the passed in south-west and north-east corners fills an image
+// of the passed in width and height (in pixels), with north up.  If the north-east corner lies west of the
+// south-west corner, the box is considered to cross the antimeridian.  Every hole of the Polygon becomes a subpath,
+// as does every ring of its points closed by repeating its first point, so holes are drawn with the evenodd fill rule.
+// Coordinates are rounded to hundredths of a pixel.
+func (p *Polygon) ToSVGPath(proj Projection, width, height int, sw, ne *Point) string {
+	crosses := ne.lng < sw.lng
+	// Points west of a box crossing the antimeridian are moved east of it, to be drawn to its right
+	project := func(point *Point) (x, y float64) {
+		if crosses && point.lng < sw.lng {
+			return proj(NewPoint(point.lat, point.lng+360))
+		}
+		return proj(point)
+	}
+	minX, minY := project(sw)
+	maxX, maxY := project(ne)
+	if crosses {
+		maxX, maxY = proj(NewPoint(ne.lat, ne.lng+360))
+	}
+	scaleX := float64(width) / (maxX - minX)
+	scaleY := float64(height) / (maxY - minY)
+
+	var path strings.Builder
+	for _, ring := range p.rings() {
+		var ringStart *Point
+		for _, point := range ring {
+			x, y := project(point)
+			command := "L"
+			if ringStart == nil {
+				command = "M"
+				ringStart = point
+			} else if point.lat == ringStart.lat && point.lng == ringStart.lng {
+				path.WriteString(" Z")
+				ringStart = nil
+				continue
+			}
+
+			if path.Len() > 0 {
+				path.WriteByte(' ')
+			}
+			path.WriteString(command + svgNumber((x-minX)*scaleX) + "," + svgNumber((maxY-y)*scaleY))
+		}
+		if ringStart != nil {
+			path.WriteString(" Z")
+		}
+	}
+	return path.String()
+}
+
+// Renders the passed in number rounded to two decimal places, without trailing zeros.
+func svgNumber(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100+0, 'f', -1, 64)
+}
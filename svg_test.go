@@ -0,0 +1,54 @@
+package geo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Ensures that a Polygon is drawn as a closed SVG path scaled to the image.
+func TestToSVGPath(t *testing.T) {
+	p := NewPolygon([]*Point{NewPoint(0, 0), NewPoint(0, 10), NewPoint(10, 10), NewPoint(10, 0)})
+	path := p.ToSVGPath(Equirectangular, 200, 100, NewPoint(0, 0), NewPoint(10, 20))
+
+	if !strings.HasPrefix(path, "M") || !strings.Contains(path, " L") || !strings.HasSuffix(path, " Z") {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a closed path, but got %q", path))
+	}
+	if expected := "M0,100 L100,100 L100,0 L0,0 Z"; path != expected {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %q, but got %q", expected, path))
+	}
+}
+
+// Ensures that the rings of a Polygon with holes become subpaths.
+func TestToSVGPathHoles(t *testing.T) {
+	p := NewPolygon([]*Point{
+		NewPoint(0, 0), NewPoint(0, 4), NewPoint(4, 4), NewPoint(4, 0), NewPoint(0, 0),
+		NewPoint(1, 1), NewPoint(2, 1), NewPoint(2, 2), NewPoint(1, 1),
+	})
+	path := p.ToSVGPath(Equirectangular, 4, 4, NewPoint(0, 0), NewPoint(4, 4))
+	expected := "M0,4 L4,4 L4,0 L0,0 Z M1,3 L1,2 L2,2 Z"
+	if path != expected {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %q, but got %q", expected, path))
+	}
+
+	p = NewPolygonWithHoles(p.Points()[:4], []*Point{NewPoint(1, 1), NewPoint(2, 1), NewPoint(2, 2)})
+	if path = p.ToSVGPath(Equirectangular, 4, 4, NewPoint(0, 0), NewPoint(4, 4)); path != expected {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %q, but got %q", expected, path))
+	}
+}
+
+// Ensures that polygons are projected and drawn across the antimeridian.
+func TestToSVGPathProjections(t *testing.T) {
+	p := NewPolygon([]*Point{NewPoint(-10, 170), NewPoint(-10, -170), NewPoint(10, -170), NewPoint(10, 170)})
+	path := p.ToSVGPath(Equirectangular, 40, 40, NewPoint(-10, 170), NewPoint(10, -170))
+	if expected := "M0,40 L40,40 L40,0 L0,0 Z"; path != expected {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %q, but got %q", expected, path))
+	}
+
+	// Mercator stretches latitudes away from the equator
+	p = NewPolygon([]*Point{NewPoint(0, 0), NewPoint(0, 10), NewPoint(60, 10), NewPoint(60, 0)})
+	path = p.ToSVGPath(WebMercator, 100, 100, NewPoint(0, 0), NewPoint(80, 10))
+	if expected := "M0,100 L100,100 L100,45.94 L0,45.94 Z"; path != expected {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %q, but got %q", expected, path))
+	}
+}
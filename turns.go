@@ -0,0 +1,131 @@
+package geo
+
+import (
+	"math"
+)
+
+// A Turn is a change of direction at a vertex of a route.
+type Turn struct {
+	// Index of the vertex in the route
+	Index int
+	// Signed change of bearing in degrees, positive for right and negative for left turns.
+	// U-turns are always reported as +180.
+	Change float64
+	// Distance in sea miles travelled along the route from its start up to the vertex
+	Distance float64
+}
+
+// Returns the signed change (in degrees) from one compass bearing to another,
+// positive when turning right and negative when turning left, in the range (-180, 180].
+// A complete reversal is always reported as a right turn of +180.
+func BearingDelta(from float64, to float64) float64 {
+	delta := math.Mod(to-from, 360.)
+	if delta <= -180. {
+		delta += 360.
+	} else if delta > 180. {
+		delta -= 360.
+	}
+	return delta
+}
+
+// Calculates the final bearing (in degrees) when arriving at the supplied point
+// along the great circle from 'this' point, which differs from the initial bearing.
+// Original Implementation from: http://www.movable-type.co.uk/scripts/latlong.html
+func (p *Point) finalBearingTo(p2 *Point) float64 {
+	return math.Mod(p2.BearingTo(p)+180., 360.)
+}
+
+// Returns the turns along the passed in route whose change of bearing
+// is at least minTurnDeg degrees in either direction.
+// The change at a vertex is measured from the final bearing of the leg arriving at it
+// to the initial bearing of the leg leaving it.
+func TurnPoints(path []*Point, minTurnDeg float64) []Turn {
+	turns := []Turn{}
+	walkTurns(path, func(turn Turn) {
+		if math.Abs(turn.Change) >= minTurnDeg {
+			turns = append(turns, turn)
+		}
+	})
+	return turns
+}
+
+// Returns the turns along the passed in route like TurnPoints does, but also
+// coalesces consecutive turns which are each smaller than minTurnDeg degrees.
+// Their changes of bearing are summed up, and once the sum reaches minTurnDeg
+// in either direction, a single Turn carrying the sum is reported at the vertex where that happened.
+// Any turn reported by TurnPoints resets the sum.
+func CoalescedTurnPoints(path []*Point, minTurnDeg float64) []Turn {
+	turns := []Turn{}
+	sum := 0.0
+	walkTurns(path, func(turn Turn) {
+		if math.Abs(turn.Change) >= minTurnDeg {
+			turns = append(turns, turn)
+			sum = 0
+			return
+		}
+
+		sum += turn.Change
+		if math.Abs(sum) >= minTurnDeg {
+			turn.Change = sum
+			turns = append(turns, turn)
+			sum = 0
+		}
+	})
+	return turns
+}
+
+// Returns the sum of the signed changes of bearing (in degrees) at the interior vertices of the passed in route,
+// positive for right and negative for left turns like BearingDelta.  A route ending where it started
+// also turns at that point, from its last leg into its first one, so a loop traversed clockwise totals
+// about +360 and one traversed counter clockwise about -360.
+func TotalTurning(path []*Point) float64 {
+	if n := len(path); n > 3 && path[0].lat == path[n-1].lat && path[0].lng == path[n-1].lng {
+		path = append(path[:n:n], path[1])
+	}
+
+	total := 0.0
+	walkTurns(path, func(turn Turn) {
+		total += turn.Change
+	})
+	return total
+}
+
+// Returns how straight the passed in route is, as the ratio of the great circle distance from its start
+// to its end over the sum of the great circle distances of its legs.  It is 1 for a straight route and the
+// lower the more winding the route is.  Returns 0 for a route returning to its start and for a route
+// without any length, e.g. of fewer than two points.
+func Straightness(path []*Point) float64 {
+	if len(path) < 2 {
+		return 0
+	}
+
+	length := 0.0
+	for i := 1; i < len(path); i++ {
+		length += path[i-1].GreatCircleDistance(path[i])
+	}
+	if length == 0 {
+		return 0
+	}
+	return math.Min(path[0].GreatCircleDistance(path[len(path)-1])/length, 1)
+}
+
+// Calls fn with the Turn at each interior vertex of the passed in route.
+// Consecutive coincident vertices are merged into one, whose Turn is reported at the index of the first of them.
+func walkTurns(path []*Point, fn func(turn Turn)) {
+	// The index of the first vertex of every run of coincident ones
+	runs := []int{}
+	for i, p := range path {
+		if len(runs) == 0 || path[runs[len(runs)-1]].GreatCircleDistance(p) != 0 {
+			runs = append(runs, i)
+		}
+	}
+
+	distance := 0.0
+	for i := 1; i < len(runs)-1; i++ {
+		prev, current, next := path[runs[i-1]], path[runs[i]], path[runs[i+1]]
+		distance += prev.GreatCircleDistance(current)
+
+		change := BearingDelta(prev.finalBearingTo(current), current.BearingTo(next))
+		fn(Turn{Index: runs[i], Change: change, Distance: distance})
+	}
+}
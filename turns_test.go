@@ -0,0 +1,159 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Ensures that the changes between bearings are signed and wrap around north.
+func TestBearingDelta(t *testing.T) {
+	var deltatests = []struct {
+		from float64
+		to   float64
+		out  float64
+	}{
+		{0, 90, 90},
+		{90, 0, -90},
+		{350, 10, 20},
+		{10, 350, -20},
+		{0, 180, 180},
+		{180, 0, 180},
+		{90, 270, 180},
+		{270, 90, 180},
+		{45, 45, 0},
+	}
+
+	for _, tt := range deltatests {
+		if delta := BearingDelta(tt.from, tt.to); math.Abs(delta-tt.out) > 0.000001 {
+			t.Errorf("Expected the change from %f to %f degrees to be %f, but got %f instead", tt.from, tt.to, tt.out, delta)
+		}
+	}
+}
+
+// Ensures that driving around a rectangular block produces four left turns of ~90 degrees.
+func TestTurnPointsRectangle(t *testing.T) {
+	path := []*Point{
+		NewPoint(0, 0.5),
+		NewPoint(0, 1),
+		NewPoint(1, 1),
+		NewPoint(1, 0),
+		NewPoint(0, 0),
+		NewPoint(0, 0.5),
+	}
+
+	turns := TurnPoints(path, 45)
+	if len(turns) != 4 {
+		t.Fatalf("Expected 4 turns, but got %d instead", len(turns))
+	}
+
+	distance := 0.0
+	for i, turn := range turns {
+		distance += path[i].GreatCircleDistance(path[i+1])
+
+		if turn.Index != i+1 {
+			t.Errorf("Expected turn %d to be at vertex %d, but got %d instead", i, i+1, turn.Index)
+		}
+		if math.Abs(turn.Change+90) > 0.01 {
+			t.Error("Unnacceptable change of bearing.", fmt.Sprintf("%f", turn.Change))
+		}
+		if math.Abs(turn.Distance-distance) > 0.000001 {
+			t.Error("Unnacceptable distance.", fmt.Sprintf("%f", turn.Distance))
+		}
+	}
+}
+
+// Ensures that gentle turns are skipped, but can be coalesced into a single turn.
+func TestTurnPointsCoalesced(t *testing.T) {
+	origin := NewPoint(10, 10)
+
+	// A gentle curve to the right of 4 turns of 20 degrees each
+	path := []*Point{origin}
+	bearing := 0.0
+	for i := 0; i < 5; i++ {
+		path = append(path, path[len(path)-1].PointAtDistanceAndBearing(1, bearing))
+		bearing += 20
+	}
+
+	if turns := TurnPoints(path, 30); len(turns) != 0 {
+		t.Errorf("Expected no turns, but got %d instead", len(turns))
+	}
+
+	turns := CoalescedTurnPoints(path, 30)
+	if len(turns) != 2 {
+		t.Fatalf("Expected 2 coalesced turns, but got %d instead", len(turns))
+	}
+	if turns[0].Index != 2 || math.Abs(turns[0].Change-40) > 0.1 {
+		t.Error("Unnacceptable coalesced turn.", fmt.Sprintf("%d %f", turns[0].Index, turns[0].Change))
+	}
+
+	// A U-turn has a deterministic sign
+	uturn := []*Point{NewPoint(0, 0), NewPoint(1, 0), NewPoint(0, 0)}
+	turns = TurnPoints(uturn, 90)
+	if len(turns) != 1 || turns[0].Change != 180 {
+		t.Errorf("Expected a single U-turn of +180 degrees, but got %v instead", turns)
+	}
+}
+
+// Ensures that the turns around a square loop add up to a full turn, signed by the direction of travel.
+func TestTotalTurning(t *testing.T) {
+	square := []*Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(1, 0), NewPoint(0, 0)}
+	if total := TotalTurning(square); math.Abs(total+360) > 0.1 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected about -360 degrees counter clockwise, but got %f", total))
+	}
+
+	reversed := []*Point{NewPoint(0, 0), NewPoint(1, 0), NewPoint(1, 1), NewPoint(0, 1), NewPoint(0, 0)}
+	if total := TotalTurning(reversed); math.Abs(total-360) > 0.1 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected about +360 degrees clockwise, but got %f", total))
+	}
+
+	// An open route only turns at its interior vertices
+	if total := TotalTurning(square[:4]); math.Abs(total+180) > 0.1 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected about -180 degrees, but got %f", total))
+	}
+	if total := TotalTurning(square[:2]); total != 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected no turning for a single leg, but got %f", total))
+	}
+}
+
+// Ensures that a straight route has a straightness of 1, and that winding routes have less.
+func TestStraightness(t *testing.T) {
+	straight := []*Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(0, 2), NewPoint(0, 3)}
+	if s := Straightness(straight); math.Abs(s-1) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a straightness of 1, but got %f", s))
+	}
+
+	// Each leg of the zig-zag runs at 45 degrees to the direction of travel
+	zigzag := []*Point{NewPoint(0, 0), NewPoint(1, 1), NewPoint(0, 2), NewPoint(1, 3), NewPoint(0, 4)}
+	if s := Straightness(zigzag); math.Abs(s-math.Sqrt(0.5)) > 0.001 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a straightness of about 0.707, but got %f", s))
+	}
+
+	loop := []*Point{NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1), NewPoint(0, 0)}
+	for _, path := range [][]*Point{loop, {NewPoint(1, 1), NewPoint(1, 1)}, {NewPoint(1, 1)}, nil} {
+		if s := Straightness(path); s != 0 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected a straightness of 0 for %v, but got %f", path, s))
+		}
+	}
+}
+
+// Ensures that the turn at a vertex repeated by duplicate fixes is reported once, at its first index.
+func TestTurnPointsDuplicates(t *testing.T) {
+	a, b, c := NewPoint(0, 0), NewPoint(0, 1), NewPoint(1, 1)
+	path := []*Point{a, a, b, NewPoint(0, 1), NewPoint(0, 1), c, c}
+
+	turns := TurnPoints(path, 45)
+	if len(turns) != 1 {
+		t.Fatalf("Expected a single turn, but got %v instead", turns)
+	}
+	if turns[0].Index != 2 || math.Abs(turns[0].Change+90) > 0.01 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected a left turn at vertex 2, but got %v", turns[0]))
+	}
+	if distance := a.GreatCircleDistance(b); math.Abs(turns[0].Distance-distance) > 0.000001 {
+		t.Error("Unnacceptable distance.", fmt.Sprintf("%f", turns[0].Distance))
+	}
+
+	if turns := TurnPoints([]*Point{a, b, b, c}, 45); len(turns) != 1 || turns[0].Index != 1 {
+		t.Errorf("Expected a single turn at vertex 1, but got %v instead", turns)
+	}
+}
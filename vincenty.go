@@ -0,0 +1,78 @@
+package geo
+
+import (
+	"errors"
+	"math"
+)
+
+// The number of iterations after which VincentyDistance gives up on converging.
+var VincentyMaxIterations = 1000
+
+// Returned by VincentyDistance when the formula fails to converge, so callers can fall back to GreatCircleDistance.
+var ErrVincentyNotConverged = errors.New("Vincenty's formula failed to converge for nearly antipodal points")
+
+// The change in longitude on the auxiliary sphere (in radians) below which VincentyDistance has converged.
+const vincentyThreshold = 1e-12
+
+// Calculates the distance (in sea miles) between 'this' point and the passed in point on the WGS-84 ellipsoid,
+// using Vincenty's inverse formula.  This is accurate to well below a meter, where GreatCircleDistance,
+// assuming a spherical earth, can be off by up to 0.5%.
+// Returns ErrVincentyNotConverged for nearly antipodal points, for which the formula fails to converge
+// within VincentyMaxIterations iterations.
+func (p *Point) VincentyDistance(p2 *Point) (float64, error) {
+	const a = WGS84SemiMajorAxis
+	const f = WGS84Flattening
+	const b = a * (1 - f)
+
+	L := (p2.lng - p.lng) * math.Pi / 180.0
+	U1 := math.Atan((1 - f) * math.Tan(p.lat*math.Pi/180.0))
+	U2 := math.Atan((1 - f) * math.Tan(p2.lat*math.Pi/180.0))
+	sinU1, cosU1 := math.Sincos(U1)
+	sinU2, cosU2 := math.Sincos(U2)
+
+	lambda := L
+	var sinSigma, cosSigma, sigma, cosSqAlpha, cos2SigmaM float64
+	converged := false
+	for i := 0; i < VincentyMaxIterations; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma = math.Sqrt((cosU2*sinLambda)*(cosU2*sinLambda) +
+			(cosU1*sinU2-sinU1*cosU2*cosLambda)*(cosU1*sinU2-sinU1*cosU2*cosLambda))
+		if sinSigma == 0 {
+			// Coincident points
+			return 0, nil
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha = 1 - sinAlpha*sinAlpha
+		cos2SigmaM = 0
+		if cosSqAlpha != 0 {
+			// Both points lie on the equator otherwise
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+		C := f / 16 * cosSqAlpha * (4 + f*(4-3*cosSqAlpha))
+		previous := lambda
+		lambda = L + (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cos2SigmaM+C*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-previous) < vincentyThreshold {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return 0, ErrVincentyNotConverged
+	}
+
+	uSq := cosSqAlpha * (a*a - b*b) / (b * b)
+	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	deltaSigma := B * sinSigma * (cos2SigmaM + B/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+		B/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+	return b * A * (sigma - deltaSigma) / metersPerSeaMile, nil
+}
+
+// Calculates the distance (in sea miles) between 'this' point and the passed in point on the WGS-84 ellipsoid.
+// alias for Point.VincentyDistance()
+func (p *Point) VincentyDistanceTo(p2 *Point) (float64, error) {
+	return p.VincentyDistance(p2)
+}
@@ -0,0 +1,80 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Ensures that Vincenty's formula reproduces a known geodesic distance to within a millimeter.
+func TestVincentyDistance(t *testing.T) {
+	// Flinders Peak to Buninyong, 54972.271 meters according to Vincenty's paper
+	flinders := NewPoint(-(37 + 57/60.0 + 3.72030/3600), 144+25/60.0+29.52440/3600)
+	buninyong := NewPoint(-(37 + 39/60.0 + 10.15610/3600), 143+55/60.0+35.38390/3600)
+	dist, err := flinders.VincentyDistance(buninyong)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(dist*metersPerSeaMile-54972.271) > 0.001 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected 54972.271 meters, but got %v", dist*metersPerSeaMile))
+	}
+
+	// Within 0.5% of the spherical approximation, and symmetric
+	if haversine := flinders.GreatCircleDistance(buninyong); math.Abs(dist-haversine)/dist > 0.005 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected about %v, but got %v", haversine, dist))
+	}
+	if back, _ := buninyong.VincentyDistance(flinders); math.Abs(back-dist) > 1e-9 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected the same distance in both directions, but got %v and %v", dist, back))
+	}
+}
+
+// Ensures that Vincenty's formula handles coincident and equatorial points, and fails for nearly antipodal ones.
+func TestVincentyDistanceEdgeCases(t *testing.T) {
+	p := NewPoint(40.7486, -73.9864)
+	if dist, err := p.VincentyDistance(NewPoint(40.7486, -73.9864)); err != nil || dist != 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected 0 between coincident points, but got %v (%v)", dist, err))
+	}
+
+	// Along the equator, the distance is the arc of the semi-major axis
+	dist, err := NewPoint(0, 0).VincentyDistance(NewPoint(0, 90))
+	if expected := WGS84SemiMajorAxis * math.Pi / 2 / metersPerSeaMile; err != nil || math.Abs(dist-expected) > 1e-6 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v, but got %v (%v)", expected, dist, err))
+	}
+
+	if _, err := NewPoint(0, 0).VincentyDistance(NewPoint(0.5, 179.7)); err != ErrVincentyNotConverged {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected ErrVincentyNotConverged for nearly antipodal points, but got %v", err))
+	}
+}
+
+// Ensures that VincentyDistanceTo reproduces known geodesic distances to within a meter.
+func TestVincentyDistanceTo(t *testing.T) {
+	cases := []struct {
+		from, to *Point
+		meters   float64
+	}{
+		// JFK to LHR and Wellington to Salamanca, as computed by GeographicLib
+		{NewPoint(40.6, -73.8), NewPoint(51.6, -0.5), 5551759.400},
+		{NewPoint(-41.32, 174.81), NewPoint(40.96, -5.50), 19959679.267},
+		// LAX to JFK
+		{NewPoint(33.9425, -118.408056), NewPoint(40.639722, -73.778889), 3982946.825},
+	}
+	for _, c := range cases {
+		dist, err := c.from.VincentyDistanceTo(c.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(dist*metersPerSeaMile-c.meters) > 1 {
+			t.Error("Unnacceptable result.", fmt.Sprintf("Expected %v meters from %v to %v, but got %v", c.meters, c.from, c.to, dist*metersPerSeaMile))
+		}
+	}
+
+	// Callers can fall back to the spherical approximation
+	from, to := NewPoint(0, 0), NewPoint(0.5, 179.7)
+	dist, err := from.VincentyDistanceTo(to)
+	if err == ErrVincentyNotConverged {
+		dist = from.GreatCircleDistance(to)
+	}
+	if dist < 10700 || dist > 10820 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected about half the circumference of the earth, but got %v", dist))
+	}
+}
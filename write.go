@@ -0,0 +1,90 @@
+package geo
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// Buffers reused by the Write functions, so that writing a point does not allocate.
+var writeBuffers = sync.Pool{New: func() interface{} {
+	b := make([]byte, 0, 4096)
+	return &b
+}}
+
+// Writes the current Point formatted like Format to the passed in writer, without allocating.
+// Returns the number of bytes written.
+func (p *Point) WriteFormat(w io.Writer, format Format) (int, error) {
+	buf := writeBuffers.Get().(*[]byte)
+	defer writeBuffers.Put(buf)
+
+	b, err := p.AppendFormat((*buf)[:0], format)
+	*buf = b
+	if err != nil {
+		return 0, err
+	}
+	return w.Write(b)
+}
+
+// Writes the current Point rendered like MarshalJSON to the passed in writer, without allocating
+// unless the keys in JSONFieldNames need escaping.  Returns the number of bytes written.
+func (p *Point) WriteJSON(w io.Writer) (int, error) {
+	buf := writeBuffers.Get().(*[]byte)
+	defer writeBuffers.Put(buf)
+
+	b, err := appendJSONKey((*buf)[:0], JSONFieldNames.Lat)
+	if err != nil {
+		return 0, err
+	}
+	split := len(b)
+	if b, err = appendJSONKey(b, JSONFieldNames.Lng); err != nil {
+		return 0, err
+	}
+	keys := len(b)
+	b = p.appendJSON(b, b[:split], b[split:keys], defaultPrecision)
+	*buf = b
+	return w.Write(b[keys:])
+}
+
+// Appends the passed in key encoded as a JSON string, exactly like json.Marshal would, to the passed in buffer.
+func appendJSONKey(dst []byte, key string) ([]byte, error) {
+	for i := 0; i < len(key); i++ {
+		if c := key[i]; c < 0x20 || c >= 0x80 || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			encoded, err := json.Marshal(key)
+			return append(dst, encoded...), err
+		}
+	}
+	dst = append(dst, '"')
+	dst = append(dst, key...)
+	return append(dst, '"'), nil
+}
+
+// Writes the passed in points to the passed in writer as CSV, one "latitude,longitude" line per point,
+// with the passed in number of decimals, or as few as needed to be exact for ShortestPrecision.  With the
+// FormatDecimals of a PrecisionPolicy, each line matches its Format with DecimalDegrees.
+// The output is buffered, so that no point allocates.
+func WritePointsCSV(w io.Writer, points []*Point, precision int) error {
+	buf := writeBuffers.Get().(*[]byte)
+	defer writeBuffers.Put(buf)
+
+	b := (*buf)[:0]
+	for _, p := range points {
+		b = strconv.AppendFloat(b, p.lat, 'f', precision, 64)
+		b = append(b, ',')
+		b = strconv.AppendFloat(b, p.lng, 'f', precision, 64)
+		b = append(b, '\n')
+		if len(b) >= cap(*buf)-64 {
+			if _, err := w.Write(b); err != nil {
+				return err
+			}
+			b = b[:0]
+		}
+	}
+	*buf = b
+	if len(b) == 0 {
+		return nil
+	}
+	_, err := w.Write(b)
+	return err
+}
@@ -0,0 +1,26 @@
+//go:build !race
+
+// The race detector allocates on its own, so this is only checked without it.
+
+package geo
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"testing"
+)
+
+// Ensures that writing points does not allocate.
+func TestWriteAllocations(t *testing.T) {
+	p := NewPoint(math.Pi, -math.E)
+	points := writePoints(100)
+	allocs := testing.AllocsPerRun(100, func() {
+		p.WriteFormat(io.Discard, DecimalSeconds)
+		p.WriteJSON(io.Discard)
+		WritePointsCSV(io.Discard, points, 6)
+	})
+	if allocs != 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected no allocations, but got %v", allocs))
+	}
+}
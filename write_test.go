@@ -0,0 +1,123 @@
+package geo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// Returns random points, along with some whose formatting is easy to get wrong.
+func writePoints(n int) []*Point {
+	r := rand.New(rand.NewSource(7))
+	points := []*Point{NewPoint(0, 0), NewPoint(-0.0000001, 179.9999999), NewPoint(-90, -180), NewPoint(1e-7, 1e21)}
+	for len(points) < n {
+		points = append(points, NewPoint(r.Float64()*180-90, r.Float64()*360-180))
+	}
+	return points
+}
+
+// Ensures that writing a point produces exactly the same bytes as formatting it.
+func TestWriteFormat(t *testing.T) {
+	for _, p := range writePoints(200) {
+		for _, format := range []Format{DecimalDegrees, DecimalMinutes, DecimalSeconds} {
+			expected, _ := p.Format(format)
+			var buf bytes.Buffer
+			n, err := p.WriteFormat(&buf, format)
+			if err != nil || buf.String() != expected || n != len(expected) {
+				t.Fatal("Unnacceptable result.", fmt.Sprintf("Expected %q, but got %q (%d bytes, %v)", expected, buf.String(), n, err))
+			}
+		}
+	}
+
+	// The degrees match what the format used to render with fmt
+	p := NewPoint(40.7486, -73.9864)
+	if s, _ := p.Format(DecimalDegrees); s != fmt.Sprintf("%f,%f", p.lat, p.lng) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %f,%f, but got %s", p.lat, p.lng, s))
+	}
+
+	if _, err := p.WriteFormat(io.Discard, Format(42)); err == nil {
+		t.Error("Expected an error writing an invalid format")
+	}
+}
+
+// Ensures that writing a point as JSON produces exactly the same bytes as marshalling it.
+func TestWriteJSON(t *testing.T) {
+	for _, p := range writePoints(200) {
+		expected, _ := p.MarshalJSON()
+		if reference := fmt.Sprintf(`{"lat":%v, "lng":%v}`, p.lat, p.lng); string(expected) != reference {
+			t.Fatal("Unnacceptable result.", fmt.Sprintf("Expected %s, but got %s", reference, expected))
+		}
+		var buf bytes.Buffer
+		n, err := p.WriteJSON(&buf)
+		if err != nil || buf.String() != string(expected) || n != len(expected) {
+			t.Fatal("Unnacceptable result.", fmt.Sprintf("Expected %s, but got %s (%d bytes, %v)", expected, buf.String(), n, err))
+		}
+	}
+
+	// Keys needing escapes are encoded like json.Marshal does
+	defer func(names FieldNames) { JSONFieldNames = names }(JSONFieldNames)
+	JSONFieldNames = FieldNames{Lat: "<lat>", Lng: `"lng"`}
+	p := NewPoint(1.5, -2)
+	expected, _ := p.MarshalJSON()
+	var buf bytes.Buffer
+	p.WriteJSON(&buf)
+	if buf.String() != string(expected) || !strings.Contains(buf.String(), `\u003clat\u003e`) {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected %s, but got %s", expected, buf.String()))
+	}
+}
+
+// Ensures that points are written as CSV lines matching the decimal degrees format.
+func TestWritePointsCSV(t *testing.T) {
+	points := writePoints(1000)
+	var buf bytes.Buffer
+	if err := WritePointsCSV(&buf, points, 6); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(points) {
+		t.Fatalf("Expected %d lines, but got %d", len(points), len(lines))
+	}
+	for i, p := range points {
+		if expected, _ := p.Format(DecimalDegrees); lines[i] != expected {
+			t.Fatal("Unnacceptable result.", fmt.Sprintf("Expected line %d to be %q, but got %q", i, expected, lines[i]))
+		}
+	}
+
+	buf.Reset()
+	WritePointsCSV(&buf, []*Point{NewPoint(1.25, -0.5)}, -1)
+	if buf.String() != "1.25,-0.5\n" {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected 1.25,-0.5, but got %q", buf.String()))
+	}
+
+	buf.Reset()
+	if err := WritePointsCSV(&buf, nil, 6); err != nil || buf.Len() != 0 {
+		t.Error("Unnacceptable result.", fmt.Sprintf("Expected no output writing no points, but got %q (%v)", buf.String(), err))
+	}
+}
+
+func BenchmarkWriteFormat(b *testing.B) {
+	p := NewPoint(40.7486, -73.9864)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p.WriteFormat(io.Discard, DecimalMinutes)
+	}
+}
+
+func BenchmarkWriteJSON(b *testing.B) {
+	p := NewPoint(40.7486, -73.9864)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p.WriteJSON(io.Discard)
+	}
+}
+
+func BenchmarkWritePointsCSV(b *testing.B) {
+	points := writePoints(1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		WritePointsCSV(io.Discard, points, 6)
+	}
+}